@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
+)
+
+func TestAgentLogListDateFlags(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	older := time.Now().UTC().Add(-2 * time.Hour)
+	newer := older.Add(time.Hour)
+	srv.AddAgentLog(agentlog.AgentLog{ID: "l1", AssetID: "a1", CreatedAt: &older})
+	srv.AddAgentLog(agentlog.AgentLog{ID: "l2", AssetID: "a1", CreatedAt: &newer})
+
+	before := older.Add(30 * time.Minute).Format(time.RFC3339)
+	out, err := runCLI(t, srv, "agent", "log", "list", "--format", "json", "--date-before", before)
+	if err != nil {
+		t.Fatalf("agent log list --date-before error = %v", err)
+	}
+	var logs models.PaginationResponse[agentlog.AgentLog]
+	if err := json.Unmarshal([]byte(out), &logs); err != nil || len(logs.Data) != 1 || logs.Data[0].ID != "l1" {
+		t.Errorf("agent log list --date-before = %s, want l1 only", out)
+	}
+
+	if _, err := runCLI(t, srv, "agent", "log", "list", "--date-after", "2024-13-01"); err == nil || !strings.HasPrefix(err.Error(), "invalid date-after format") {
+		t.Errorf("agent log list with an invalid date error = %v", err)
+	}
+}
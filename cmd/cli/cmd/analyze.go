@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/analysis"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	pkgExecutions "github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	pkgMitre "github.com/fourcorelabs/attack-sdk-go/pkg/mitre"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/report"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// analyzeCmd represents the analyze command
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze results across executions",
+	Long:  `Commands for measuring detection coverage across many executions in the FourCore platform.`,
+}
+
+// analyzeGapsCmd represents the analyze gaps command
+var analyzeGapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "Rank detection gaps across executions",
+	Long: `Aggregates the steps of every execution in a date range by action, MITRE ATT&CK technique, platform or
+asset, and ranks the groups by detection rate so that attacks which succeed but are never detected come first.
+Rates other than the success rate are percentages of the steps that succeeded.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+		byStr, _ := cmd.Flags().GetString("by")
+		limit, _ := cmd.Flags().GetInt("limit")
+		mitreDays, _ := cmd.Flags().GetInt("mitre-days")
+
+		by, err := analysis.ParseDimension(byStr)
+		if err != nil {
+			return err
+		}
+
+		opts, err := getAnalysisExecutionOpts(cmd)
+		if err != nil {
+			return err
+		}
+
+		// --- API Call ---
+		reports, err := analysis.FetchReports(cmd.Context(), client, opts)
+		if err != nil {
+			return analysisAPIError(err)
+		}
+
+		var techniques report.TechniqueMap
+		if by == analysis.ByTechnique {
+			coverage, err := pkgMitre.GetAllMitreCoverage(cmd.Context(), client, mitreDays)
+			if err != nil {
+				return analysisAPIError(fmt.Errorf("failed to retrieve MITRE ATT&CK coverage: %w", err))
+			}
+			techniques = report.NewTechniqueMap(coverage)
+		}
+
+		gapReport := analysis.Gaps(reports, techniques)
+		gaps := gapReport.By(by)
+		if limit > 0 && len(gaps) > limit {
+			gaps = gaps[:limit]
+		}
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			return printGapsJSON(gaps)
+		case "csv":
+			return analysis.WriteGapsCSV(os.Stdout, gaps)
+		default:
+			printGapsTable(gapReport, by, gaps)
+			return nil
+		}
+	},
+}
+
+// analyzeTTDCmd represents the analyze ttd command
+var analyzeTTDCmd = &cobra.Command{
+	Use:   "ttd",
+	Short: "Show time-to-detect statistics across executions",
+	Long: `Computes how long the security stack took to raise an alert for the steps of every execution in a date range,
+from the start of each step to the detection time of its earliest correlated alert. Shows min, median and p95
+per integration, action and execution, and lists the slowest detections as outliers.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+		limit, _ := cmd.Flags().GetInt("limit")
+		threshold, _ := cmd.Flags().GetDuration("outlier-threshold")
+
+		opts, err := getAnalysisExecutionOpts(cmd)
+		if err != nil {
+			return err
+		}
+
+		// --- API Call ---
+		reports, err := analysis.FetchReports(cmd.Context(), client, opts)
+		if err != nil {
+			return analysisAPIError(err)
+		}
+
+		ttd := analysis.TimeToDetect(reports, analysis.TTDOpts{OutlierThreshold: threshold})
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			return printTTDJSON(ttd)
+		default:
+			printTTDTables(ttd, limit)
+			return nil
+		}
+	},
+}
+
+func init() {
+	// Add commands to the analyze command
+	analyzeCmd.AddCommand(analyzeGapsCmd)
+	analyzeCmd.AddCommand(analyzeTTDCmd)
+
+	// Add analyze command to root command
+	rootCmd.AddCommand(analyzeCmd)
+
+	// --- Common Flags ---
+	// Execution selection flags for commands that analyze executions
+	for _, cmd := range []*cobra.Command{analyzeGapsCmd, analyzeTTDCmd} {
+		cmd.Flags().String("date-after", "", "Analyze executions created after specified date (RFC3339 format, default 30 days ago)")
+		cmd.Flags().String("date-before", "", "Analyze executions created before specified date (RFC3339 format)")
+		cmd.Flags().StringArray("execution-type", []string{}, "Filter by execution type (endpoint_security, data_exfil, firewall, email_infiltration, waf)")
+		cmd.Flags().StringArray("asset-id", []string{}, "Filter by asset ID (can be specified multiple times)")
+		cmd.Flags().IntP("limit", "l", 20, "Number of rows to show per table, 0 for all")
+	}
+
+	// --- Command-specific Flags ---
+	// Gaps command flags
+	analyzeGapsCmd.Flags().StringP("format", "f", "table", "Output format (table, json, csv)")
+	analyzeGapsCmd.Flags().String("by", string(analysis.ByTechnique), "Group steps by action, technique, platform or asset")
+	analyzeGapsCmd.Flags().Int("mitre-days", 30, "Number of days of MITRE ATT&CK coverage used to map actions to techniques (max 60)")
+
+	// TTD command flags
+	analyzeTTDCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	analyzeTTDCmd.Flags().Duration("outlier-threshold", 0, "Time-to-detect above which a step is an outlier (default Q3 + 1.5 × IQR)")
+}
+
+// getAnalysisExecutionOpts builds the options selecting the executions to analyze from the flags of cmd
+func getAnalysisExecutionOpts(cmd *cobra.Command) (pkgExecutions.ExecutionOpts, error) {
+	executionTypeStrs, _ := cmd.Flags().GetStringArray("execution-type")
+	assetIDs, _ := cmd.Flags().GetStringArray("asset-id")
+
+	dateAfter, dateBefore, err := getDateFlags(cmd)
+	if err != nil {
+		return pkgExecutions.ExecutionOpts{}, err
+	}
+	if dateAfter.IsZero() {
+		dateAfter = time.Now().AddDate(0, 0, -30)
+	}
+
+	opts := pkgExecutions.ExecutionOpts{
+		Order:      "DESC",
+		AssetIDs:   assetIDs,
+		DateAfter:  dateAfter,
+		DateBefore: dateBefore,
+	}
+
+	for _, s := range executionTypeStrs {
+		executionType, err := models.ParseExecutionType(s)
+		if err != nil {
+			return opts, err
+		}
+		opts.ExecutionType = append(opts.ExecutionType, executionType)
+	}
+
+	return opts, nil
+}
+
+// analysisAPIError maps the errors of fetching executions to analyze to CLI errors
+func analysisAPIError(err error) error {
+	if errors.Is(err, api.ErrApiKeyInvalid) {
+		return fmt.Errorf("API request failed: Invalid API Key")
+	}
+	if errors.Is(err, api.ErrRateLimited) {
+		return fmt.Errorf("API request failed: rate limited, try a narrower date range: %w", err)
+	}
+	return err
+}
+
+// --- Helper Functions for Output Formatting ---
+
+func printGapsJSON(gaps []analysis.Gap) error {
+	if gaps == nil {
+		gaps = []analysis.Gap{}
+	}
+	jsonData, err := json.MarshalIndent(gaps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printGapsTable(r analysis.GapReport, by analysis.Dimension, gaps []analysis.Gap) {
+	fmt.Printf("Executions: %d, Steps: %d\n", r.Executions, r.Steps)
+	if by == analysis.ByTechnique && r.Unmapped > 0 {
+		fmt.Printf("Steps without a MITRE ATT&CK technique: %d\n", r.Unmapped)
+	}
+	fmt.Println()
+
+	if len(gaps) == 0 {
+		fmt.Println("No steps found matching the criteria.")
+		return
+	}
+
+	neverDetected := false
+	tbl := table.New("Rank", strings.ToUpper(string(by)[:1])+string(by)[1:], "Name", "Executions", "Steps", "Succeeded", "Undetected", "Success %", "Detection %", "Logging %", "Alert %")
+	for i, g := range gaps {
+		rank := fmt.Sprintf("%d", i+1)
+		if g.NeverDetected() {
+			rank += " !"
+			neverDetected = true
+		}
+		tbl.AddRow(
+			rank,
+			g.Key,
+			valueOrDefault(g.Name, "N/A"),
+			g.Executions,
+			g.Steps,
+			g.Succeeded,
+			g.Undetected,
+			fmt.Sprintf("%.1f", g.SuccessRate),
+			fmt.Sprintf("%.1f", g.DetectionRate),
+			fmt.Sprintf("%.1f", g.LoggingRate),
+			fmt.Sprintf("%.1f", g.AlertRate),
+		)
+	}
+
+	// Print the table to stdout
+	tbl.Print()
+	if neverDetected {
+		fmt.Println("\n! succeeded but never detected")
+	}
+}
+
+func printTTDJSON(ttd analysis.TTDReport) error {
+	jsonData, err := json.MarshalIndent(ttd, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printTTDTables(ttd analysis.TTDReport, limit int) {
+	if ttd.Overall.Count == 0 {
+		fmt.Println("No detected steps found matching the criteria.")
+		if ttd.Undetected > 0 {
+			fmt.Printf("Succeeded steps without an alert: %d\n", ttd.Undetected)
+		}
+		if ttd.Unmeasured > 0 {
+			fmt.Printf("Alerted steps without a measurable detection time: %d\n", ttd.Unmeasured)
+		}
+		return
+	}
+
+	fmt.Printf("Detected steps: %d, Succeeded steps without an alert: %d, Alerted steps without a measurable detection time: %d\n",
+		ttd.Overall.Count, ttd.Undetected, ttd.Unmeasured)
+	fmt.Printf("Time to detect: min %s, median %s, p95 %s, max %s\n",
+		formatTTD(ttd.Overall.Min), formatTTD(ttd.Overall.Median), formatTTD(ttd.Overall.P95), formatTTD(ttd.Overall.Max))
+
+	for _, section := range []struct {
+		title, key string
+		groups     []analysis.GroupTTD
+	}{
+		{"By Integration", "Integration", ttd.Integrations},
+		{"By Action", "Action", ttd.Actions},
+		{"By Execution", "Execution", ttd.Executions},
+	} {
+		fmt.Printf("\n%s:\n", section.title)
+		tbl := table.New(section.key, "Name", "Count", "Min", "Median", "P95", "Max")
+		for i, g := range section.groups {
+			if limit > 0 && i >= limit {
+				break
+			}
+			tbl.AddRow(g.Key, valueOrDefault(g.Name, "N/A"), g.Count, formatTTD(g.Min), formatTTD(g.Median), formatTTD(g.P95), formatTTD(g.Max))
+		}
+		tbl.Print()
+	}
+
+	if len(ttd.Outliers) == 0 {
+		return
+	}
+	fmt.Printf("\nOutliers (above %s):\n", formatTTD(ttd.OutlierThreshold))
+	tbl := table.New("TTD", "Execution ID", "Hostname", "Action", "Name", "Integration", "Alert")
+	for i, m := range ttd.Outliers {
+		if limit > 0 && i >= limit {
+			break
+		}
+		tbl.AddRow(formatTTD(m.TTD), m.ExecutionID, valueOrDefault(m.Hostname, m.AssetID), m.ActionID, valueOrDefault(m.Name, "N/A"), m.IntegrationType, valueOrDefault(m.AlertName, "N/A"))
+	}
+	tbl.Print()
+}
+
+// formatTTD formats a time-to-detect rounded to the second, or to the millisecond below a second
+func formatTTD(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
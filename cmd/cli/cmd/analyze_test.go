@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/analysis"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+func TestAnalyzeGaps(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	yes, no := true, false
+	step := func(actionID string, success, detected *bool) models.GetExecutionResponseAssetStep {
+		return models.GetExecutionResponseAssetStep{ActionID: actionID, Name: actionID, Success: success, Detected: detected, Done: &yes}
+	}
+	for i, steps := range [][]models.GetExecutionResponseAssetStep{
+		{step("detected", &yes, &yes), step("undetected", &yes, &no)},
+		{step("detected", &yes, &yes), step("undetected", &yes, &no), step("blocked", &no, &no)},
+	} {
+		created := time.Now().UTC().Add(-time.Duration(i+1) * time.Hour)
+		srv.AddExecution(models.GetExecutionResponse{
+			Status:    models.StatusFinished,
+			CreatedAt: &created,
+			Assets:    []models.AssetExecutionDetails{{AssetID: "a1", Hostname: "host-1", Platform: "windows", Steps: steps}},
+		})
+	}
+
+	out, err := runCLI(t, srv, "analyze", "gaps", "--by", "action", "--format", "json")
+	if err != nil {
+		t.Fatalf("analyze gaps error = %v", err)
+	}
+
+	var gaps []analysis.Gap
+	if err := json.Unmarshal([]byte(out), &gaps); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(gaps) != 3 {
+		t.Fatalf("%d gaps, want 3: %s", len(gaps), out)
+	}
+
+	// The action that succeeded but was never detected ranks first
+	worst := gaps[0]
+	if worst.Key != "undetected" || worst.Executions != 2 || worst.Succeeded != 2 || worst.Undetected != 2 || !worst.NeverDetected() {
+		t.Errorf("worst gap = %+v, want the undetected action", worst)
+	}
+	for _, g := range gaps[1:] {
+		if g.Key == "detected" && (g.Detected != 2 || g.DetectionRate != 100) {
+			t.Errorf("detected gap = %+v, want 2 detections", g)
+		}
+	}
+}
+
+func TestAnalyzeGapsInvalidDimension(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	if _, err := runCLI(t, srv, "analyze", "gaps", "--by", "colour"); err == nil {
+		t.Error("analyze gaps --by colour succeeded")
+	}
+	if n := len(srv.Calls()); n != 0 {
+		t.Errorf("%d API calls made for an invalid dimension", n)
+	}
+}
+
+func TestAnalyzeDateFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantAfter  time.Time
+		wantBefore string
+	}{
+		{
+			name:      "defaults to the last 30 days",
+			wantAfter: time.Now().AddDate(0, 0, -30),
+		},
+		{
+			name:       "explicit range",
+			args:       []string{"--date-after", "2026-01-01T00:00:00Z", "--date-before", "2026-02-01T00:00:00Z"},
+			wantAfter:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantBefore: "2026-02-01T00:00:00Z",
+		},
+		{
+			name:       "only date-before keeps the default start",
+			args:       []string{"--date-before", "2026-02-01T00:00:00Z"},
+			wantAfter:  time.Now().AddDate(0, 0, -30),
+			wantBefore: "2026-02-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeserver.New()
+			defer srv.Close()
+
+			if _, err := runCLI(t, srv, append([]string{"analyze", "ttd", "--format", "json"}, tt.args...)...); err != nil {
+				t.Fatalf("analyze ttd error = %v", err)
+			}
+
+			calls := srv.Calls()
+			if len(calls) == 0 {
+				t.Fatal("no executions were listed")
+			}
+			query := calls[0].Query
+			after, err := time.Parse(time.RFC3339, query.Get("date_after"))
+			if err != nil || after.Sub(tt.wantAfter).Abs() > time.Minute {
+				t.Errorf("date_after = %q, want %s", query.Get("date_after"), tt.wantAfter.Format(time.RFC3339))
+			}
+			if got := query.Get("date_before"); got != tt.wantBefore {
+				t.Errorf("date_before = %q, want %q", got, tt.wantBefore)
+			}
+		})
+	}
+}
+
+func TestAnalyzeInvalidDate(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	_, err := runCLI(t, srv, "analyze", "gaps", "--date-after", "last week")
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid date-after format") {
+		t.Errorf("analyze gaps with an invalid date error = %v", err)
+	}
+	if n := len(srv.Calls()); n != 0 {
+		t.Errorf("%d API calls made for an invalid date", n)
+	}
+}
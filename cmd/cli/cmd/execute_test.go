@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/asset"
+)
+
+// updateCreatedExecution applies fn to the first execution once the CLI has created it, closing the
+// returned channel when done
+func updateCreatedExecution(srv *fakeserver.Server, fn func(*models.GetExecutionResponse)) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 500 {
+			if executions := srv.Executions(); len(executions) > 0 {
+				srv.UpdateExecution(executions[0].ID, fn)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	return done
+}
+
+func TestExecuteActionWait(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	srv.AddAsset(asset.Asset{ID: "a1", SystemInfo: &asset.AssetSystemInfo{Hostname: "host-1"}})
+
+	done := updateCreatedExecution(srv, func(e *models.GetExecutionResponse) {
+		e.Status, e.StatusState, e.Progress = models.StatusFinished, models.StatusFinished, 100
+	})
+
+	out, err := runCLI(t, srv, "action", "endpoint", "action-1", "--assets", "a1", "--wait", "--wait-timeout", "30s")
+	<-done
+	if err != nil {
+		t.Fatalf("action endpoint --wait error = %v", err)
+	}
+
+	var execution models.GetExecutionResponse
+	if err := json.Unmarshal([]byte(out), &execution); err != nil {
+		t.Fatalf("output is not the final report: %v\n%s", err, out)
+	}
+	if execution.Status != models.StatusFinished || execution.Progress != 100 {
+		t.Errorf("final report status = %s, progress = %v, want finished at 100%%", execution.Status, execution.Progress)
+	}
+	if !slices.Equal(execution.ActionIDs, []string{"action-1"}) || len(execution.Assets) != 1 || execution.Assets[0].Hostname != "host-1" {
+		t.Errorf("final report = %+v, want action-1 run on host-1", execution)
+	}
+}
+
+func TestExecuteActionWaitFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*models.GetExecutionResponse)
+		wantErr string
+	}{
+		{
+			name: "execution failure ends the wait",
+			update: func(e *models.GetExecutionResponse) {
+				e.FailError = "no stager available"
+			},
+			wantErr: "execution failed: no stager available",
+		},
+		{
+			name: "asset failure in the finished report",
+			update: func(e *models.GetExecutionResponse) {
+				e.Status, e.StatusState = models.StatusFinished, models.StatusFinished
+				e.Assets[0].FailError = map[string]any{"message": "agent disconnected"}
+			},
+			wantErr: `execution failed: asset host-1: {"message":"agent disconnected"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeserver.New()
+			defer srv.Close()
+			srv.AddAsset(asset.Asset{ID: "a1", SystemInfo: &asset.AssetSystemInfo{Hostname: "host-1"}})
+
+			done := updateCreatedExecution(srv, tt.update)
+			out, err := runCLI(t, srv, "action", "endpoint", "action-1", "--assets", "a1", "--wait", "--wait-timeout", "30s")
+			<-done
+
+			if !errors.Is(err, executions.ErrExecutionFailed) || !strings.HasSuffix(err.Error(), tt.wantErr) {
+				t.Fatalf("action endpoint --wait error = %v, want %q", err, tt.wantErr)
+			}
+
+			// The final report is still printed
+			var execution models.GetExecutionResponse
+			if err := json.Unmarshal([]byte(out), &execution); err != nil || execution.ID == "" {
+				t.Errorf("output is not the final report: %v\n%s", err, out)
+			}
+		})
+	}
+}
+
+func TestExecuteActionWithoutWait(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	out, err := runCLI(t, srv, "action", "endpoint", "action-1", "--assets", "a1")
+	if err != nil {
+		t.Fatalf("action endpoint error = %v", err)
+	}
+
+	var execution models.GetExecutionResponse
+	if err := json.Unmarshal([]byte(out), &execution); err != nil || execution.Status != models.StatusInProgress {
+		t.Errorf("action endpoint output = %s, want the in progress execution", out)
+	}
+	if n := len(srv.Executions()); n != 1 {
+		t.Errorf("%d executions created, want 1", n)
+	}
+}
@@ -1,608 +1,1031 @@
-package cmd
-
-import (
-	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"strings"
-	"time"
-
-	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
-	pkgExecutions "github.com/fourcorelabs/attack-sdk-go/pkg/executions" // Alias to avoid collision
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
-	"github.com/rodaine/table"
-	"github.com/spf13/cobra"
-)
-
-// executionsCmd represents the executions command
-var executionsCmd = &cobra.Command{
-	Use:   "executions",
-	Short: "Execution operations",
-	Long:  `Commands for interacting with executions in the FourCore platform.`,
-}
-
-// executionsListCmd represents the executions list command
-var executionsListCmd = &cobra.Command{
-	Use:     "list",
-	Aliases: []string{"ls"},
-	Short:   "List executions",
-	Long:    `Retrieves and displays executions with options for pagination, ordering, filtering, and formatting.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// --- Validation ---
-		if apiKeyVal == "" {
-			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
-		}
-
-		// --- API Client ---
-		client, err := api.NewHTTPAPI(baseUrlVal, apiKeyVal)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
-		}
-
-		// --- Get Flags ---
-		size, _ := cmd.Flags().GetInt("size")
-		offset, _ := cmd.Flags().GetInt("offset")
-		order, _ := cmd.Flags().GetString("order")
-		format, _ := cmd.Flags().GetString("format")
-		name, _ := cmd.Flags().GetString("name")
-		status, _ := cmd.Flags().GetString("status")
-		assetIDs, _ := cmd.Flags().GetStringArray("asset-id")
-		hostnames, _ := cmd.Flags().GetStringArray("hostname")
-		chainIDs, _ := cmd.Flags().GetStringArray("chain-id")
-		attackIDs, _ := cmd.Flags().GetStringArray("attack-id")
-		executionTypes, _ := cmd.Flags().GetStringArray("execution-type")
-		dateAfterStr, _ := cmd.Flags().GetString("date-after")
-		dateBeforeStr, _ := cmd.Flags().GetString("date-before")
-
-		// Parse date-after and date-before if provided
-		var dateAfter, dateBefore time.Time
-		if dateAfterStr != "" {
-			dateAfter, err = time.Parse(time.RFC3339, dateAfterStr)
-			if err != nil {
-				return fmt.Errorf("invalid date-after format, must be RFC3339 format (e.g., 2023-01-01T00:00:00Z): %w", err)
-			}
-		}
-		if dateBeforeStr != "" {
-			dateBefore, err = time.Parse(time.RFC3339, dateBeforeStr)
-			if err != nil {
-				return fmt.Errorf("invalid date-before format, must be RFC3339 format (e.g., 2023-01-01T00:00:00Z): %w", err)
-			}
-		}
-
-		opts := pkgExecutions.ExecutionOpts{
-			Size:          size,
-			Offset:        offset,
-			Order:         strings.ToUpper(order),
-			Name:          name,
-			Status:        status,
-			AssetIDs:      assetIDs,
-			Hostnames:     hostnames,
-			ChainIDs:      chainIDs,
-			AttackIDs:     attackIDs,
-			ExecutionType: executionTypes,
-			DateAfter:     dateAfter,
-			DateBefore:    dateBefore,
-		}
-
-		// --- API Call ---
-		executions, err := pkgExecutions.GetExecutions(context.Background(), client, opts)
-		if err != nil {
-			// Check for specific API errors
-			if errors.Is(err, api.ErrApiKeyInvalid) {
-				return fmt.Errorf("API request failed: Invalid API Key")
-			}
-			if errors.Is(err, api.ErrRateLimited) {
-				return fmt.Errorf("API request failed: Rate limit exceeded (%w)", err)
-			}
-			return fmt.Errorf("failed to retrieve executions: %w", err)
-		}
-
-		// --- Output ---
-		switch strings.ToLower(format) {
-		case "json":
-			return printExecutionsJSON(executions)
-		case "table":
-			fallthrough
-		default:
-			printExecutionsTable(executions)
-			return nil
-		}
-	},
-}
-
-// executionsGetCmd represents the executions get command
-var executionsGetCmd = &cobra.Command{
-	Use:   "get [execution_id]",
-	Short: "Get execution report",
-	Long:  `Retrieves detailed execution report for a specific execution.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// --- Validation ---
-		if apiKeyVal == "" {
-			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
-		}
-
-		executionID := args[0]
-		if executionID == "" {
-			return fmt.Errorf("execution ID is required")
-		}
-
-		// --- API Client ---
-		client, err := api.NewHTTPAPI(baseUrlVal, apiKeyVal)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
-		}
-
-		// --- Get Flags ---
-		format, _ := cmd.Flags().GetString("format")
-
-		// --- API Call ---
-		execution, err := pkgExecutions.GetExecutionReport(context.Background(), client, executionID)
-		if err != nil {
-			// Check for specific API errors
-			if errors.Is(err, api.ErrApiKeyInvalid) {
-				return fmt.Errorf("API request failed: Invalid API Key")
-			}
-			if errors.Is(err, api.ErrNotFound) {
-				return fmt.Errorf("execution not found: %s", executionID)
-			}
-			return fmt.Errorf("failed to retrieve execution report: %w", err)
-		}
-
-		// --- Output ---
-		switch strings.ToLower(format) {
-		case "json":
-			return printExecutionJSON(execution)
-		default:
-			printExecutionItemDetails(execution)
-			return nil
-		}
-	},
-}
-
-// executionsGetCmd represents the executions get command
-var executionsGetDetectionCmd = &cobra.Command{
-	Use:   "steps [execution_id]",
-	Short: "Get execution step report",
-	Long:  `Retrieves execution steps report for a specific execution.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// --- Validation ---
-		if apiKeyVal == "" {
-			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
-		}
-
-		executionID := args[0]
-		if executionID == "" {
-			return fmt.Errorf("execution ID is required")
-		}
-
-		// --- API Client ---
-		client, err := api.NewHTTPAPI(baseUrlVal, apiKeyVal)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
-		}
-
-		// --- Get Flags ---
-		format, _ := cmd.Flags().GetString("format")
-
-		// --- API Call ---
-		execution, err := pkgExecutions.GetExecutionStepReport(context.Background(), client, executionID)
-		if err != nil {
-			// Check for specific API errors
-			if errors.Is(err, api.ErrApiKeyInvalid) {
-				return fmt.Errorf("API request failed: Invalid API Key")
-			}
-			if errors.Is(err, api.ErrNotFound) {
-				return fmt.Errorf("execution not found: %s", executionID)
-			}
-			return fmt.Errorf("failed to retrieve execution report: %w", err)
-		}
-
-		// --- Output ---
-		switch strings.ToLower(format) {
-		case "json":
-			return printExecutionStepJSON(execution)
-		default:
-			printStepReportDetails(execution)
-			return nil
-		}
-	},
-}
-
-// executionsDeleteCmd represents the executions delete command
-var executionsDeleteCmd = &cobra.Command{
-	Use:   "delete [execution_id]",
-	Short: "Delete an execution",
-	Long:  `Deletes a specific execution from the FourCore platform.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// --- Validation ---
-		if apiKeyVal == "" {
-			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
-		}
-
-		executionID := args[0]
-		if executionID == "" {
-			return fmt.Errorf("execution ID is required")
-		}
-
-		// Confirm deletion if confirm flag not set
-		confirm, _ := cmd.Flags().GetBool("confirm")
-		if !confirm {
-			fmt.Printf("Are you sure you want to delete execution %s? (y/N): ", executionID)
-			var response string
-			fmt.Scanln(&response)
-			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-				fmt.Println("Deletion cancelled.")
-				return nil
-			}
-		}
-
-		// --- API Client ---
-		client, err := api.NewHTTPAPI(baseUrlVal, apiKeyVal)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
-		}
-
-		// --- API Call ---
-		response, err := pkgExecutions.DeleteExecution(context.Background(), client, executionID)
-		if err != nil {
-			// Check for specific API errors
-			if errors.Is(err, api.ErrApiKeyInvalid) {
-				return fmt.Errorf("API request failed: Invalid API Key")
-			}
-			if errors.Is(err, api.ErrNotFound) {
-				return fmt.Errorf("execution not found: %s", executionID)
-			}
-			return fmt.Errorf("failed to delete execution: %w", err)
-		}
-
-		// --- Output Success ---
-		if response.Success {
-			fmt.Printf("Successfully deleted execution: %s\n", executionID)
-		} else {
-			fmt.Printf("No changes made to execution: %s\n", executionID)
-		}
-		return nil
-	},
-}
-
-func init() {
-	// Add commands to the executions command
-	executionsCmd.AddCommand(executionsListCmd)
-	executionsCmd.AddCommand(executionsGetCmd)
-	executionsCmd.AddCommand(executionsDeleteCmd)
-	executionsCmd.AddCommand(executionsGetDetectionCmd)
-
-	// Add executions command to root command
-	rootCmd.AddCommand(executionsCmd)
-
-	// --- Common Flags ---
-	// Format flag for commands that output data
-	executionsGetDetectionCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
-	executionsListCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
-	executionsGetCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
-
-	// --- Command-specific Flags ---
-	// List command flags
-	executionsListCmd.Flags().IntP("size", "s", 10, "Number of executions to retrieve")
-	executionsListCmd.Flags().IntP("offset", "o", 0, "Offset for pagination")
-	executionsListCmd.Flags().StringP("order", "r", "DESC", "Order of executions (ASC or DESC)")
-	executionsListCmd.Flags().StringP("name", "n", "", "Filter by name")
-	executionsListCmd.Flags().StringP("status", "", "", "Filter by status (inprogress, finished, unknown)")
-	executionsListCmd.Flags().StringArrayP("asset-id", "a", []string{}, "Filter by asset ID (can be specified multiple times)")
-	executionsListCmd.Flags().StringArray("hostname", []string{}, "Filter by hostname (can be specified multiple times)")
-	executionsListCmd.Flags().StringArray("chain-id", []string{}, "Filter by chain ID (can be specified multiple times)")
-	executionsListCmd.Flags().StringArray("attack-id", []string{}, "Filter by attack ID (can be specified multiple times)")
-	executionsListCmd.Flags().StringArray("execution-type", []string{}, "Filter by execution type (endpoint_security, data_exfil, firewall, email_infiltration, waf)")
-	executionsListCmd.Flags().String("date-after", "", "Filter executions created after specified date (RFC3339 format)")
-	executionsListCmd.Flags().String("date-before", "", "Filter executions created before specified date (RFC3339 format)")
-
-	// Delete command flags
-	executionsDeleteCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt")
-}
-
-// --- Helper Functions for Output Formatting ---
-
-func printExecutionsTable(executions models.ListWithCountExecutions) {
-	if executions.Count == 0 || len(executions.Data) == 0 {
-		fmt.Println("No executions found matching the criteria.")
-		return
-	}
-
-	fmt.Printf("Total Executions: %d\n\n", executions.Count)
-
-	// Create a new table with headers
-	tbl := table.New("ID", "Attack Name", "Status", "Success", "Detection Rate", "Assets", "Created At", "Updated At")
-
-	for _, execution := range executions.Data {
-		// Format progress as percentage
-		progress := fmt.Sprintf("%.1f%%", execution.Progress)
-
-		// Format detection rate as percentage
-		detectionRate := fmt.Sprintf("%.1f%%", execution.Detected)
-
-		// Format asset count
-		assetCount := fmt.Sprintf("%d", execution.AssetCount)
-
-		// Format created at
-		createdAt := "N/A"
-		if execution.CreatedAt != nil {
-			createdAt = execution.CreatedAt.Format(time.RFC3339)
-		}
-
-		updatedAt := "N/A"
-		if execution.UpdatedAt != nil {
-			createdAt = execution.UpdatedAt.Format(time.RFC3339)
-		}
-
-		// Truncate long attack names
-		attackName := execution.AttackName
-
-		// Add row data
-		tbl.AddRow(
-			execution.ID,
-			attackName,
-			execution.StatusState,
-			progress,
-			detectionRate,
-			assetCount,
-			createdAt,
-			updatedAt,
-		)
-	}
-
-	// Print the table to stdout
-	tbl.Print()
-}
-
-func printExecutionsJSON(executions models.ListWithCountExecutions) error {
-	jsonData, err := json.MarshalIndent(executions, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to format JSON output: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
-}
-
-func printExecutionJSON(execution models.GetExecutionResponse) error {
-	jsonData, err := json.MarshalIndent(execution, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to format JSON output: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
-}
-
-func printExecutionStepJSON(execution []models.ExecutionStepDetections) error {
-	jsonData, err := json.MarshalIndent(execution, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to format JSON output: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
-}
-
-// printStepReportDetails formats and prints the details for a slice of ExecutionStepDetections,
-// maintaining a consistent, aligned structure for clear readability in the terminal.
-func printStepReportDetails(steps []models.ExecutionStepDetections) {
-	fmt.Println("Step Report Details:")
-
-	if len(steps) == 0 {
-		fmt.Println("  No steps found.")
-		return
-	}
-
-	for i, step := range steps {
-		fmt.Printf("\n--- Step %d ---\n", i+1)
-
-		// --- Basic Step and Asset Information ---
-		fmt.Printf("Step Name:        %s\n", step.Name)
-		fmt.Printf("Asset Hostname:   %s\n", step.Hostname)
-		fmt.Printf("Asset ID:         %s\n", step.AssetID)
-
-		// --- Optional Step Description ---
-		if step.Description != "" {
-			// Handle multi-line descriptions gracefully
-			descLines := strings.Split(step.Description, "\n")
-			if len(descLines) > 1 {
-				fmt.Printf("Description:      %s\n", descLines[0])
-				for _, line := range descLines[1:] {
-					fmt.Printf("                  %s\n", line)
-				}
-			} else {
-				fmt.Printf("Description:      %s\n", step.Description)
-			}
-		}
-
-		// --- Events ---
-		if len(step.Events) > 0 {
-			fmt.Printf("\nEvents:\n")
-			for _, event := range step.Events {
-				fmt.Printf("  - [%s] Type: %s, Data: %s\n",
-					event.EventTime.Format(time.RFC3339),
-					event.Type,
-					strings.TrimSpace(event.Data))
-			}
-		}
-
-		// --- Output ---
-		if step.Output != nil && step.Output.Output != nil {
-			fmt.Printf("\nOutput:\n")
-			// The output can be complex; we'll format it as a string with indentation.
-			outputStr := fmt.Sprintf("%v", step.Output.Output)
-			fmt.Printf("  %s\n", strings.TrimSpace(outputStr))
-		}
-
-		// --- Files ---
-		if len(step.Files) > 0 {
-			fmt.Printf("\nFiles:\n")
-			for _, file := range step.Files {
-				if file.Valid {
-					fmt.Printf("  - Bucket: %s, Object: %s\n", file.Bucket, file.Object)
-				}
-			}
-		}
-
-		// --- Indicators of Compromise (IOCs) ---
-		if len(step.IOC) > 0 {
-			fmt.Printf("\nIndicators of Compromise (IOCs):\n")
-			for _, ioc := range step.IOC {
-				fmt.Printf("  - Type: %-10s | IOC: %v\n", ioc.IOCType, ioc.IOC)
-			}
-		}
-
-		// --- Alerts (Correlations) ---
-		if len(step.Correlations) > 0 {
-			fmt.Printf("\nAlerts (Correlations):\n")
-			for _, alert := range step.Correlations {
-				fmt.Printf("  - [%s] %s (Source: %s)\n",
-					alert.Severity,
-					alert.Name,
-					alert.Source)
-				if alert.Description != "" {
-					fmt.Printf("    Description: %s\n", alert.Description)
-				}
-			}
-		}
-
-		// --- Rules ---
-		if len(step.Rules) > 0 {
-			fmt.Printf("\nMatched Rules:\n")
-			for _, rule := range step.Rules {
-				fmt.Printf("  - Name: %s, Type: %s, Value: %s\n", rule.Name, rule.Type, rule.Value)
-				if rule.Hash != nil {
-					fmt.Printf("    (MD5: %s, SHA1: %s, SHA256: %s)\n", rule.Hash.MD5, rule.Hash.SHA1, rule.Hash.SHA256)
-				}
-			}
-		}
-
-		// --- Recommendations ---
-		if len(step.Recommendation) > 0 {
-			fmt.Printf("\nRecommendations:\n")
-			for _, rec := range step.Recommendation {
-				fmt.Printf("  - %s: %s\n", rec.Name, rec.Value)
-			}
-		}
-
-		// --- Mitigations ---
-		if len(step.Mitigations) > 0 {
-			fmt.Printf("\nMitigations:\n")
-			for _, mitigation := range step.Mitigations {
-				fmt.Printf("  - %s: %s\n", mitigation.Name, mitigation.Description)
-			}
-		}
-	}
-}
-
-func printExecutionItemDetails(execution models.GetExecutionResponse) {
-	fmt.Println("Execution Details:")
-	fmt.Printf("ID:               %s\n", execution.ID)
-	fmt.Printf("Attack Name:      %s\n", execution.AttackName)
-	fmt.Printf("Chain ID:         %s\n", execution.ChainID)
-	fmt.Printf("Status:           %s\n", execution.StatusState)
-	fmt.Printf("Execution Type:   %s\n", execution.ExecutionType)
-	fmt.Printf("Progress:         %.1f%%\n", execution.Progress)
-	fmt.Printf("Detection Rate:   %.1f%%\n", execution.Detected)
-	fmt.Printf("Score:            %.1f\n", execution.Score)
-	fmt.Printf("Run Elevated:     %t\n", execution.RunElevated)
-
-	if execution.CreatedAt != nil {
-		fmt.Printf("Created At:       %s\n", execution.CreatedAt.Format(time.RFC3339))
-	}
-	if execution.UpdatedAt != nil {
-		fmt.Printf("Updated At:       %s\n", execution.UpdatedAt.Format(time.RFC3339))
-	}
-
-	// Organization and User info
-	if execution.OrgName != nil && *execution.OrgName != "" {
-		fmt.Printf("Organization:     %s (ID: %d)\n", *execution.OrgName, execution.OrgID)
-	}
-	if execution.Username != nil && *execution.Username != "" {
-		fmt.Printf("User:             %s (ID: %d)\n", *execution.Username, execution.UserID)
-	}
-
-	// Statistics
-	fmt.Printf("\nStatistics:\n")
-	fmt.Printf("  Total Attacks:  %d\n", execution.TotalAttacks)
-	fmt.Printf("  Total Finished: %d\n", execution.TotalFinished)
-	fmt.Printf("  Total Success:  %d\n", execution.TotalSuccess)
-	fmt.Printf("  Total Detected: %d\n", execution.TotalDetected)
-
-	// Assets
-	if len(execution.Assets) > 0 {
-		fmt.Printf("\nAssets (%d):\n", len(execution.Assets))
-		for i, asset := range execution.Assets {
-			if i < 5 { // Limit to first 5 assets to avoid overwhelming output
-				fmt.Printf("  - %s (%s) - %s\n", asset.Hostname, asset.AssetID, asset.Platform)
-			}
-		}
-		if len(execution.Assets) > 5 {
-			fmt.Printf("  ... and %d more assets\n", len(execution.Assets)-5)
-		}
-	}
-
-	// Hostname info
-	if len(execution.Hostname) > 0 {
-		fmt.Printf("\nTarget Hosts (%d):\n", len(execution.Hostname))
-		for i, host := range execution.Hostname {
-			if i < 5 { // Limit to first 5 hosts
-				fmt.Printf("  - %s (%s) - %s\n", host.Name, host.IPAddr, host.OS)
-			}
-		}
-		if len(execution.Hostname) > 5 {
-			fmt.Printf("  ... and %d more hosts\n", len(execution.Hostname)-5)
-		}
-	}
-
-	// C2 Information
-	if execution.C2Type != "" || execution.C2Profile != "" {
-		fmt.Printf("\nC2 Configuration:\n")
-		if execution.C2Type != "" {
-			fmt.Printf("  Type:           %s\n", execution.C2Type)
-		}
-		if execution.C2Profile != "" {
-			fmt.Printf("  Profile:        %s\n", execution.C2Profile)
-		}
-	}
-
-	// Attack information
-	if execution.Attack != nil {
-		fmt.Printf("\nAttack Information:\n")
-		fmt.Printf("  Attack ID:      %d\n", execution.Attack.ID)
-		fmt.Printf("  Description:    %s\n", execution.Attack.Description)
-		fmt.Printf("  Platform:       %s\n", execution.Attack.Platform)
-		if len(execution.Attack.Platforms) > 0 {
-			fmt.Printf("  Platforms:      %s\n", strings.Join(execution.Attack.Platforms, ", "))
-		}
-	}
-
-	// Integrations
-	if len(execution.Integrations) > 0 {
-		fmt.Printf("\nIntegrations:     %s\n", strings.Join(execution.Integrations, ", "))
-	}
-
-	// Action IDs
-	if len(execution.ActionIDs) > 0 {
-		fmt.Printf("\nAction IDs (%d):  ", len(execution.ActionIDs))
-		if len(execution.ActionIDs) <= 3 {
-			fmt.Printf("%s\n", strings.Join(execution.ActionIDs, ", "))
-		} else {
-			fmt.Printf("%s, ... and %d more\n", strings.Join(execution.ActionIDs[:3], ", "), len(execution.ActionIDs)-3)
-		}
-	}
-
-	// Statistics detail
-	if execution.Statistics != nil {
-		fmt.Printf("\nDetailed Statistics:\n")
-		fmt.Printf("  Assets Attacked:     %d\n", execution.Statistics.AssetsAttacked)
-		fmt.Printf("  Attack Success Rate: %.1f%%\n", execution.Statistics.AttackSuccess*100)
-		fmt.Printf("  Files Exfiltrated:   %d\n", execution.Statistics.FilesExfiltrated)
-		fmt.Printf("  Total Steps:         %d\n", execution.Statistics.TotalSteps)
-		if len(execution.Statistics.PlatformsAttacked) > 0 {
-			fmt.Printf("  Platforms Attacked:  %s\n", strings.Join(execution.Statistics.PlatformsAttacked, ", "))
-		}
-	}
-}
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	pkgExecutions "github.com/fourcorelabs/attack-sdk-go/pkg/executions" // Alias to avoid collision
+	pkgMitre "github.com/fourcorelabs/attack-sdk-go/pkg/mitre"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/report"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// executionsCmd represents the executions command
+var executionsCmd = &cobra.Command{
+	Use:   "executions",
+	Short: "Execution operations",
+	Long:  `Commands for interacting with executions in the FourCore platform.`,
+}
+
+// executionsListCmd represents the executions list command
+var executionsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List executions",
+	Long:    `Retrieves and displays executions with options for pagination, ordering, filtering, and formatting.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		size, _ := cmd.Flags().GetInt("size")
+		offset, _ := cmd.Flags().GetInt("offset")
+		order, _ := cmd.Flags().GetString("order")
+		format, _ := cmd.Flags().GetString("format")
+		name, _ := cmd.Flags().GetString("name")
+		assetIDs, _ := cmd.Flags().GetStringArray("asset-id")
+		hostnames, _ := cmd.Flags().GetStringArray("hostname")
+		chainIDs, _ := cmd.Flags().GetStringArray("chain-id")
+		attackIDs, _ := cmd.Flags().GetStringArray("attack-id")
+		executionTypeStrs, _ := cmd.Flags().GetStringArray("execution-type")
+		all, _ := cmd.Flags().GetBool("all")
+
+		orderBy, filters, err := getQueryFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		status, err := getStatusFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		var executionTypes []models.ExecutionType
+		for _, s := range executionTypeStrs {
+			t, err := models.ParseExecutionType(s)
+			if err != nil {
+				return err
+			}
+			executionTypes = append(executionTypes, t)
+		}
+
+		dateAfter, dateBefore, err := getDateFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		opts := pkgExecutions.ExecutionOpts{
+			Size:          size,
+			Offset:        offset,
+			Order:         strings.ToUpper(order),
+			Name:          name,
+			Status:        status,
+			AssetIDs:      assetIDs,
+			Hostnames:     hostnames,
+			ChainIDs:      chainIDs,
+			AttackIDs:     attackIDs,
+			ExecutionType: executionTypes,
+			DateAfter:     dateAfter,
+			DateBefore:    dateBefore,
+			OrderBy:       orderBy,
+			Filters:       filters,
+		}
+
+		// --- API Call ---
+		var executions models.ListWithCountExecutions
+		if all {
+			executions.Data, err = api.Collect(pkgExecutions.All(cmd.Context(), client, opts, api.WithPrefetch()))
+			executions.Count = len(executions.Data)
+		} else {
+			executions, err = pkgExecutions.GetExecutions(cmd.Context(), client, opts)
+		}
+		if err != nil {
+			// Check for specific API errors
+			if errors.Is(err, api.ErrApiKeyInvalid) {
+				return fmt.Errorf("API request failed: Invalid API Key")
+			}
+			if errors.Is(err, api.ErrRateLimited) {
+				return fmt.Errorf("API request failed: Rate limit exceeded (%w)", err)
+			}
+			return fmt.Errorf("failed to retrieve executions: %w", err)
+		}
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			return printExecutionsJSON(executions)
+		case "table":
+			fallthrough
+		default:
+			printExecutionsTable(executions)
+			return nil
+		}
+	},
+}
+
+// executionsGetCmd represents the executions get command
+var executionsGetCmd = &cobra.Command{
+	Use:   "get [execution_id]",
+	Short: "Get execution report",
+	Long:  `Retrieves detailed execution report for a specific execution.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		executionID := args[0]
+		if executionID == "" {
+			return fmt.Errorf("execution ID is required")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+
+		// --- API Call ---
+		execution, err := pkgExecutions.GetExecutionReport(cmd.Context(), client, executionID)
+		if err != nil {
+			// Check for specific API errors
+			if errors.Is(err, api.ErrApiKeyInvalid) {
+				return fmt.Errorf("API request failed: Invalid API Key")
+			}
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("execution not found: %s", executionID)
+			}
+			return fmt.Errorf("failed to retrieve execution report: %w", err)
+		}
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			return printExecutionJSON(execution)
+		case "junit":
+			return report.WriteJUnit(os.Stdout, execution)
+		case "sarif":
+			return report.WriteSARIF(os.Stdout, execution)
+		case "markdown", "md":
+			r, err := newMarkdownRenderer(cmd, report.MarkdownExecutionTemplate)
+			if err != nil {
+				return err
+			}
+			return r.Execution(os.Stdout, execution, report.MarkdownOpts{})
+		default:
+			printExecutionItemDetails(execution)
+			return nil
+		}
+	},
+}
+
+// executionsGetCmd represents the executions get command
+var executionsGetDetectionCmd = &cobra.Command{
+	Use:   "steps [execution_id]",
+	Short: "Get execution step report",
+	Long: `Retrieves execution steps report for a specific execution, including the action steps nested in
+composite actions. Use --tree to show the steps of each asset as a tree.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		executionID := args[0]
+		if executionID == "" {
+			return fmt.Errorf("execution ID is required")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+		tree, _ := cmd.Flags().GetBool("tree")
+
+		// --- API Call ---
+		execution, err := pkgExecutions.GetExecutionStepReport(cmd.Context(), client, executionID)
+		if err != nil {
+			// Check for specific API errors
+			if errors.Is(err, api.ErrApiKeyInvalid) {
+				return fmt.Errorf("API request failed: Invalid API Key")
+			}
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("execution not found: %s", executionID)
+			}
+			return fmt.Errorf("failed to retrieve execution report: %w", err)
+		}
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			return printExecutionStepJSON(execution)
+		case "markdown", "md":
+			r, err := newMarkdownRenderer(cmd, report.MarkdownStepsTemplate)
+			if err != nil {
+				return err
+			}
+			return r.Steps(os.Stdout, execution)
+		default:
+			if tree {
+				printStepTree(execution)
+				return nil
+			}
+			printStepReportDetails(execution)
+			return nil
+		}
+	},
+}
+
+// executionsDeleteCmd represents the executions delete command
+var executionsDeleteCmd = &cobra.Command{
+	Use:   "delete [execution_id]",
+	Short: "Delete an execution",
+	Long:  `Deletes a specific execution from the FourCore platform.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		executionID := args[0]
+		if executionID == "" {
+			return fmt.Errorf("execution ID is required")
+		}
+
+		// Confirm deletion if confirm flag not set
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		if !confirm {
+			fmt.Printf("Are you sure you want to delete execution %s? (y/N): ", executionID)
+			var response string
+			fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+				fmt.Println("Deletion cancelled.")
+				return nil
+			}
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- API Call ---
+		response, err := pkgExecutions.DeleteExecution(cmd.Context(), client, executionID)
+		if err != nil {
+			// Check for specific API errors
+			if errors.Is(err, api.ErrApiKeyInvalid) {
+				return fmt.Errorf("API request failed: Invalid API Key")
+			}
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("execution not found: %s", executionID)
+			}
+			return fmt.Errorf("failed to delete execution: %w", err)
+		}
+
+		// --- Output Success ---
+		if response.Success {
+			fmt.Printf("Successfully deleted execution: %s\n", executionID)
+		} else {
+			fmt.Printf("No changes made to execution: %s\n", executionID)
+		}
+		return nil
+	},
+}
+
+// executionsDiffCmd represents the executions diff command
+var executionsDiffCmd = &cobra.Command{
+	Use:   "diff <base_execution_id> <execution_id>",
+	Short: "Compare the steps of two executions",
+	Long: `Compares two executions step by step, matching steps by action and asset, and reports changes in
+success, detection, logging, severity and correlations. Steps detected or logged in the base execution but
+missed in the other are reported as regressions.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+		changesOnly, _ := cmd.Flags().GetBool("changes-only")
+		failOnRegression, _ := cmd.Flags().GetBool("fail-on-regression")
+
+		// --- API Call ---
+		reports := make([]models.GetExecutionResponse, len(args))
+		for i, executionID := range args {
+			reports[i], err = pkgExecutions.GetExecutionReport(cmd.Context(), client, executionID)
+			if err != nil {
+				// Check for specific API errors
+				if errors.Is(err, api.ErrApiKeyInvalid) {
+					return fmt.Errorf("API request failed: Invalid API Key")
+				}
+				if errors.Is(err, api.ErrNotFound) {
+					return fmt.Errorf("execution not found: %s", executionID)
+				}
+				return fmt.Errorf("failed to retrieve execution report: %w", err)
+			}
+		}
+
+		comparison := pkgExecutions.Compare(reports[0], reports[1])
+		if changesOnly {
+			steps := comparison.Steps[:0]
+			for _, step := range comparison.Steps {
+				if step.Change != pkgExecutions.StepUnchanged {
+					steps = append(steps, step)
+				}
+			}
+			comparison.Steps = steps
+		}
+
+		// --- Output ---
+		switch strings.ToLower(format) {
+		case "json":
+			if err := printComparisonJSON(comparison); err != nil {
+				return err
+			}
+		default:
+			printComparisonTable(comparison)
+		}
+
+		if failOnRegression && comparison.HasRegressions() {
+			return fmt.Errorf("%d step(s) regressed between %s and %s", comparison.Regressions, comparison.BaseID, comparison.TargetID)
+		}
+		return nil
+	},
+}
+
+// executionsReportCmd represents the executions report command
+var executionsReportCmd = &cobra.Command{
+	Use:   "report <execution_id>",
+	Short: "Generate an HTML execution report",
+	Long: `Generates a self-contained HTML report of an execution with per-asset summaries, MITRE ATT&CK mapping,
+detections, mitigations, recommended rules and the event timeline. The report has no external resources
+so it can be viewed offline or sent by email.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		executionID := args[0]
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		output, _ := cmd.Flags().GetString("output")
+		title, _ := cmd.Flags().GetString("title")
+		mitreDays, _ := cmd.Flags().GetInt("mitre-days")
+		noMitre, _ := cmd.Flags().GetBool("no-mitre")
+
+		// --- API Call ---
+		execution, err := pkgExecutions.GetExecutionReport(cmd.Context(), client, executionID)
+		if err != nil {
+			// Check for specific API errors
+			if errors.Is(err, api.ErrApiKeyInvalid) {
+				return fmt.Errorf("API request failed: Invalid API Key")
+			}
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("execution not found: %s", executionID)
+			}
+			return fmt.Errorf("failed to retrieve execution report: %w", err)
+		}
+
+		opts := report.HTMLOpts{Title: title}
+		if !noMitre {
+			// The MITRE mapping is optional, so the report is still generated if it cannot be retrieved
+			coverage, err := pkgMitre.GetAllMitreCoverage(cmd.Context(), client, mitreDays)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to retrieve MITRE ATT&CK coverage, omitting technique mapping: %v\n", err)
+			} else {
+				opts.Techniques = report.NewTechniqueMap(coverage)
+			}
+		}
+
+		// --- Output ---
+		if output == "" || output == "-" {
+			return report.WriteHTML(os.Stdout, execution, opts)
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		if err := report.WriteHTML(f, execution, opts); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write report file: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Report written to %s\n", output)
+		return nil
+	},
+}
+
+// executionsIocsCmd represents the executions iocs command
+var executionsIocsCmd = &cobra.Command{
+	Use:   "iocs <execution_id>...",
+	Short: "Export the IOCs and rules of executions",
+	Long: `Exports the IOC entries, rule hashes and recommended rules of the steps of one or more executions.
+The stix format writes a STIX 2.1 bundle with an indicator per IOC and recommended rule, an attack pattern
+per MITRE ATT&CK technique and a report per execution, for import into a threat intelligence platform.
+Rule hashes identify rule content rather than files, so they are only included in the csv and json formats.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --- Validation ---
+		if apiKeyVal == "" {
+			return fmt.Errorf("API key is required. Set it using --api-key flag, FOURCORE_API_KEY environment variable, or 'config set api-key' command")
+		}
+
+		// --- API Client ---
+		client, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// --- Get Flags ---
+		format, _ := cmd.Flags().GetString("format")
+		mitreDays, _ := cmd.Flags().GetInt("mitre-days")
+		noMitre, _ := cmd.Flags().GetBool("no-mitre")
+
+		format = strings.ToLower(format)
+		if format != "json" && format != "csv" && format != "stix" {
+			return fmt.Errorf("invalid format '%s': must be one of stix, csv, json", format)
+		}
+
+		// --- API Call ---
+		reports := make([]models.GetExecutionResponse, len(args))
+		for i, executionID := range args {
+			reports[i], err = pkgExecutions.GetExecutionReport(cmd.Context(), client, executionID)
+			if err != nil {
+				// Check for specific API errors
+				if errors.Is(err, api.ErrApiKeyInvalid) {
+					return fmt.Errorf("API request failed: Invalid API Key")
+				}
+				if errors.Is(err, api.ErrNotFound) {
+					return fmt.Errorf("execution not found: %s", executionID)
+				}
+				return fmt.Errorf("failed to retrieve execution report: %w", err)
+			}
+		}
+
+		// --- Output ---
+		switch format {
+		case "stix":
+			var opts report.STIXOpts
+			if !noMitre {
+				// Attack patterns are optional, so the bundle is still exported if they cannot be retrieved
+				coverage, err := pkgMitre.GetAllMitreCoverage(cmd.Context(), client, mitreDays)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to retrieve MITRE ATT&CK coverage, omitting attack patterns: %v\n", err)
+				} else {
+					opts.Techniques = report.NewTechniqueMap(coverage)
+				}
+			}
+			return report.WriteSTIX(os.Stdout, reports, opts)
+		case "csv":
+			return report.WriteIOCsCSV(os.Stdout, report.IOCs(reports...))
+		default:
+			return printIOCsJSON(report.IOCs(reports...))
+		}
+	},
+}
+
+func init() {
+	// Add commands to the executions command
+	executionsCmd.AddCommand(executionsListCmd)
+	executionsCmd.AddCommand(executionsGetCmd)
+	executionsCmd.AddCommand(executionsDeleteCmd)
+	executionsCmd.AddCommand(executionsGetDetectionCmd)
+	executionsCmd.AddCommand(executionsDiffCmd)
+	executionsCmd.AddCommand(executionsReportCmd)
+	executionsCmd.AddCommand(executionsIocsCmd)
+
+	// Add executions command to root command
+	rootCmd.AddCommand(executionsCmd)
+
+	// --- Common Flags ---
+	// Format flag for commands that output data
+	executionsGetDetectionCmd.Flags().StringP("format", "f", "table", "Output format (table, json, markdown)")
+	executionsListCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	executionsGetCmd.Flags().StringP("format", "f", "table", "Output format (table, json, junit, markdown, sarif)")
+
+	// Template flag for commands with Markdown output
+	for _, cmd := range []*cobra.Command{executionsGetCmd, executionsGetDetectionCmd} {
+		cmd.Flags().String("template", "", "Go text/template file replacing the built-in template for --format markdown")
+	}
+
+	// --- Command-specific Flags ---
+	// Steps command flags
+	executionsGetDetectionCmd.Flags().Bool("tree", false, "Show the steps of each asset as a tree of nested action steps (table format only)")
+
+	// List command flags
+	executionsListCmd.Flags().IntP("size", "s", 10, "Number of executions to retrieve")
+	executionsListCmd.Flags().IntP("offset", "o", 0, "Offset for pagination")
+	executionsListCmd.Flags().StringP("order", "r", "DESC", "Order of executions (ASC or DESC)")
+	executionsListCmd.Flags().StringP("name", "n", "", "Filter by name")
+	executionsListCmd.Flags().StringP("status", "", "", "Filter by status (inprogress, finished, unknown)")
+	executionsListCmd.Flags().StringArrayP("asset-id", "a", []string{}, "Filter by asset ID (can be specified multiple times)")
+	executionsListCmd.Flags().StringArray("hostname", []string{}, "Filter by hostname (can be specified multiple times)")
+	executionsListCmd.Flags().StringArray("chain-id", []string{}, "Filter by chain ID (can be specified multiple times)")
+	executionsListCmd.Flags().StringArray("attack-id", []string{}, "Filter by attack ID (can be specified multiple times)")
+	executionsListCmd.Flags().StringArray("execution-type", []string{}, "Filter by execution type (endpoint_security, data_exfil, firewall, email_infiltration, waf)")
+	executionsListCmd.Flags().String("date-after", "", "Filter executions created after specified date (RFC3339 format)")
+	executionsListCmd.Flags().String("date-before", "", "Filter executions created before specified date (RFC3339 format)")
+	addQueryFlags(executionsListCmd)
+	executionsListCmd.Flags().Bool("all", false, "Retrieve all pages of executions, using --size as the page size")
+
+	// Delete command flags
+	executionsDeleteCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt")
+
+	// Diff command flags
+	executionsDiffCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	executionsDiffCmd.Flags().Bool("changes-only", false, "Only show steps that changed, were added or were removed")
+	executionsDiffCmd.Flags().Bool("fail-on-regression", false, "Exit non-zero if any step regressed")
+
+	// Report command flags
+	executionsReportCmd.Flags().StringP("output", "o", "", "File to write the HTML report to (default stdout)")
+	executionsReportCmd.Flags().String("title", "", "Report title (default the attack name)")
+	executionsReportCmd.Flags().Int("mitre-days", 30, "Number of days of MITRE ATT&CK coverage used to map actions to techniques (max 60)")
+	executionsReportCmd.Flags().Bool("no-mitre", false, "Omit the MITRE ATT&CK technique mapping")
+
+	// IOCs command flags
+	executionsIocsCmd.Flags().StringP("format", "f", "json", "Output format (stix, csv, json)")
+	executionsIocsCmd.Flags().Int("mitre-days", 30, "Number of days of MITRE ATT&CK coverage used to map actions to attack patterns (max 60)")
+	executionsIocsCmd.Flags().Bool("no-mitre", false, "Omit MITRE ATT&CK attack patterns from the STIX bundle")
+}
+
+// --- Helper Functions for Output Formatting ---
+
+func printExecutionsTable(executions models.ListWithCountExecutions) {
+	if executions.Count == 0 || len(executions.Data) == 0 {
+		fmt.Println("No executions found matching the criteria.")
+		return
+	}
+
+	fmt.Printf("Total Executions: %d\n\n", executions.Count)
+
+	// Create a new table with headers
+	tbl := table.New("ID", "Attack Name", "Status", "Success", "Detection Rate", "Assets", "Created At", "Updated At")
+
+	for _, execution := range executions.Data {
+		// Format progress as percentage
+		progress := fmt.Sprintf("%.1f%%", execution.Progress)
+
+		// Format detection rate as percentage
+		detectionRate := fmt.Sprintf("%.1f%%", execution.Detected)
+
+		// Format asset count
+		assetCount := fmt.Sprintf("%d", execution.AssetCount)
+
+		// Format created at
+		createdAt := "N/A"
+		if execution.CreatedAt != nil {
+			createdAt = execution.CreatedAt.Format(time.RFC3339)
+		}
+
+		updatedAt := "N/A"
+		if execution.UpdatedAt != nil {
+			createdAt = execution.UpdatedAt.Format(time.RFC3339)
+		}
+
+		// Truncate long attack names
+		attackName := execution.AttackName
+
+		// Add row data
+		tbl.AddRow(
+			execution.ID,
+			attackName,
+			execution.StatusState,
+			progress,
+			detectionRate,
+			assetCount,
+			createdAt,
+			updatedAt,
+		)
+	}
+
+	// Print the table to stdout
+	tbl.Print()
+}
+
+func printExecutionsJSON(executions models.ListWithCountExecutions) error {
+	jsonData, err := json.MarshalIndent(executions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printExecutionJSON(execution models.GetExecutionResponse) error {
+	jsonData, err := json.MarshalIndent(execution, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printExecutionStepJSON(execution []models.ExecutionStepDetections) error {
+	jsonData, err := json.MarshalIndent(execution, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printIOCsJSON(records []report.IOCRecord) error {
+	if records == nil {
+		records = []report.IOCRecord{}
+	}
+	jsonData, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// printStepReportDetails formats and prints the details for a slice of ExecutionStepDetections,
+// maintaining a consistent, aligned structure for clear readability in the terminal.
+func printStepReportDetails(steps []models.ExecutionStepDetections) {
+	fmt.Println("Step Report Details:")
+
+	if len(steps) == 0 {
+		fmt.Println("  No steps found.")
+		return
+	}
+
+	for i, step := range steps {
+		fmt.Printf("\n--- Step %d ---\n", i+1)
+
+		// --- Basic Step and Asset Information ---
+		fmt.Printf("Step Name:        %s\n", step.Name)
+		fmt.Printf("Asset Hostname:   %s\n", step.Hostname)
+		fmt.Printf("Asset ID:         %s\n", step.AssetID)
+		if step.Depth > 0 {
+			fmt.Printf("Parent Actions:   %s\n", strings.Join(step.ParentActionIDs, " > "))
+		}
+
+		// --- Optional Step Description ---
+		if step.Description != "" {
+			// Handle multi-line descriptions gracefully
+			descLines := strings.Split(step.Description, "\n")
+			if len(descLines) > 1 {
+				fmt.Printf("Description:      %s\n", descLines[0])
+				for _, line := range descLines[1:] {
+					fmt.Printf("                  %s\n", line)
+				}
+			} else {
+				fmt.Printf("Description:      %s\n", step.Description)
+			}
+		}
+
+		// --- Events ---
+		if len(step.Events) > 0 {
+			fmt.Printf("\nEvents:\n")
+			for _, event := range step.Events {
+				fmt.Printf("  - [%s] Type: %s, Data: %s\n",
+					event.EventTime.Format(time.RFC3339),
+					event.Type,
+					strings.TrimSpace(event.Data))
+			}
+		}
+
+		// --- Output ---
+		if step.Output != nil && step.Output.Output != nil {
+			fmt.Printf("\nOutput:\n")
+			// The output can be complex; we'll format it as a string with indentation.
+			outputStr := fmt.Sprintf("%v", step.Output.Output)
+			fmt.Printf("  %s\n", strings.TrimSpace(outputStr))
+		}
+
+		// --- Files ---
+		if len(step.Files) > 0 {
+			fmt.Printf("\nFiles:\n")
+			for _, file := range step.Files {
+				if file.Valid {
+					fmt.Printf("  - Bucket: %s, Object: %s\n", file.Bucket, file.Object)
+				}
+			}
+		}
+
+		// --- Indicators of Compromise (IOCs) ---
+		if len(step.IOC) > 0 {
+			fmt.Printf("\nIndicators of Compromise (IOCs):\n")
+			for _, ioc := range step.IOC {
+				fmt.Printf("  - Type: %-10s | IOC: %v\n", ioc.IOCType, ioc.IOC)
+			}
+		}
+
+		// --- Alerts (Correlations) ---
+		if len(step.Correlations) > 0 {
+			fmt.Printf("\nAlerts (Correlations):\n")
+			for _, alert := range step.Correlations {
+				fmt.Printf("  - [%s] %s (Source: %s)\n",
+					alert.Severity,
+					alert.Name,
+					alert.Source)
+				if alert.Description != "" {
+					fmt.Printf("    Description: %s\n", alert.Description)
+				}
+			}
+		}
+
+		// --- Rules ---
+		if len(step.Rules) > 0 {
+			fmt.Printf("\nMatched Rules:\n")
+			for _, rule := range step.Rules {
+				fmt.Printf("  - Name: %s, Type: %s, Value: %s\n", rule.Name, rule.Type, rule.Value)
+				if rule.Hash != nil {
+					fmt.Printf("    (MD5: %s, SHA1: %s, SHA256: %s)\n", rule.Hash.MD5, rule.Hash.SHA1, rule.Hash.SHA256)
+				}
+			}
+		}
+
+		// --- Recommendations ---
+		if len(step.Recommendation) > 0 {
+			fmt.Printf("\nRecommendations:\n")
+			for _, rec := range step.Recommendation {
+				fmt.Printf("  - %s: %s\n", rec.Name, rec.Value)
+			}
+		}
+
+		// --- Mitigations ---
+		if len(step.Mitigations) > 0 {
+			fmt.Printf("\nMitigations:\n")
+			for _, mitigation := range step.Mitigations {
+				fmt.Printf("  - %s: %s\n", mitigation.Name, mitigation.Description)
+			}
+		}
+	}
+}
+
+// printStepTree prints the steps of each asset as a tree, with action steps nested under their
+// composite action. Steps must be in the order returned by GetExecutionStepReport.
+func printStepTree(steps []models.ExecutionStepDetections) {
+	if len(steps) == 0 {
+		fmt.Println("No steps found.")
+		return
+	}
+
+	// last[d] reports whether the enclosing step at depth d is the last of its siblings
+	var last []bool
+	for i, step := range steps {
+		if i == 0 || step.AssetID != steps[i-1].AssetID {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s (%s, %s)\n", valueOrDefault(step.Hostname, step.AssetID), step.AssetID, valueOrDefault(step.Platform, "N/A"))
+		}
+
+		// A step is the last of its siblings if no later step of the asset is at its depth before
+		// one at a lower depth
+		isLast := true
+		for _, next := range steps[i+1:] {
+			if next.AssetID != step.AssetID || next.Depth < step.Depth {
+				break
+			}
+			if next.Depth == step.Depth {
+				isLast = false
+				break
+			}
+		}
+		for len(last) < step.Depth {
+			last = append(last, true)
+		}
+		last = append(last[:step.Depth], isLast)
+
+		var prefix strings.Builder
+		for _, l := range last[:step.Depth] {
+			if l {
+				prefix.WriteString("    ")
+			} else {
+				prefix.WriteString("│   ")
+			}
+		}
+		if isLast {
+			prefix.WriteString("└── ")
+		} else {
+			prefix.WriteString("├── ")
+		}
+
+		fmt.Printf("%s%s [%s] %s\n", prefix.String(), valueOrDefault(step.Name, "N/A"), valueOrDefault(step.ActionID, "N/A"), report.StepOutcome(step.GetExecutionResponseAssetStep))
+	}
+}
+
+func printExecutionItemDetails(execution models.GetExecutionResponse) {
+	fmt.Println("Execution Details:")
+	fmt.Printf("ID:               %s\n", execution.ID)
+	fmt.Printf("Attack Name:      %s\n", execution.AttackName)
+	fmt.Printf("Chain ID:         %s\n", execution.ChainID)
+	fmt.Printf("Status:           %s\n", execution.StatusState)
+	fmt.Printf("Execution Type:   %s\n", execution.ExecutionType)
+	fmt.Printf("Progress:         %.1f%%\n", execution.Progress)
+	fmt.Printf("Detection Rate:   %.1f%%\n", execution.Detected)
+	fmt.Printf("Score:            %.1f\n", execution.Score)
+	fmt.Printf("Run Elevated:     %t\n", execution.RunElevated)
+
+	if execution.CreatedAt != nil {
+		fmt.Printf("Created At:       %s\n", execution.CreatedAt.Format(time.RFC3339))
+	}
+	if execution.UpdatedAt != nil {
+		fmt.Printf("Updated At:       %s\n", execution.UpdatedAt.Format(time.RFC3339))
+	}
+
+	// Organization and User info
+	if execution.OrgName != nil && *execution.OrgName != "" {
+		fmt.Printf("Organization:     %s (ID: %d)\n", *execution.OrgName, execution.OrgID)
+	}
+	if execution.Username != nil && *execution.Username != "" {
+		fmt.Printf("User:             %s (ID: %d)\n", *execution.Username, execution.UserID)
+	}
+
+	// Statistics
+	fmt.Printf("\nStatistics:\n")
+	fmt.Printf("  Total Attacks:  %d\n", execution.TotalAttacks)
+	fmt.Printf("  Total Finished: %d\n", execution.TotalFinished)
+	fmt.Printf("  Total Success:  %d\n", execution.TotalSuccess)
+	fmt.Printf("  Total Detected: %d\n", execution.TotalDetected)
+
+	// Assets
+	if len(execution.Assets) > 0 {
+		fmt.Printf("\nAssets (%d):\n", len(execution.Assets))
+		for i, asset := range execution.Assets {
+			if i < 5 { // Limit to first 5 assets to avoid overwhelming output
+				fmt.Printf("  - %s (%s) - %s\n", asset.Hostname, asset.AssetID, asset.Platform)
+			}
+		}
+		if len(execution.Assets) > 5 {
+			fmt.Printf("  ... and %d more assets\n", len(execution.Assets)-5)
+		}
+	}
+
+	// Hostname info
+	if len(execution.Hostname) > 0 {
+		fmt.Printf("\nTarget Hosts (%d):\n", len(execution.Hostname))
+		for i, host := range execution.Hostname {
+			if i < 5 { // Limit to first 5 hosts
+				fmt.Printf("  - %s (%s) - %s\n", host.Name, host.IPAddr, host.OS)
+			}
+		}
+		if len(execution.Hostname) > 5 {
+			fmt.Printf("  ... and %d more hosts\n", len(execution.Hostname)-5)
+		}
+	}
+
+	// C2 Information
+	if execution.C2Type != "" || execution.C2Profile != "" {
+		fmt.Printf("\nC2 Configuration:\n")
+		if execution.C2Type != "" {
+			fmt.Printf("  Type:           %s\n", execution.C2Type)
+		}
+		if execution.C2Profile != "" {
+			fmt.Printf("  Profile:        %s\n", execution.C2Profile)
+		}
+	}
+
+	// Attack information
+	if execution.Attack != nil {
+		fmt.Printf("\nAttack Information:\n")
+		fmt.Printf("  Attack ID:      %d\n", execution.Attack.ID)
+		fmt.Printf("  Description:    %s\n", execution.Attack.Description)
+		fmt.Printf("  Platform:       %s\n", execution.Attack.Platform)
+		if len(execution.Attack.Platforms) > 0 {
+			fmt.Printf("  Platforms:      %s\n", strings.Join(execution.Attack.Platforms, ", "))
+		}
+	}
+
+	// Integrations
+	if len(execution.Integrations) > 0 {
+		fmt.Printf("\nIntegrations:     %s\n", strings.Join(execution.Integrations, ", "))
+	}
+
+	// Action IDs
+	if len(execution.ActionIDs) > 0 {
+		fmt.Printf("\nAction IDs (%d):  ", len(execution.ActionIDs))
+		if len(execution.ActionIDs) <= 3 {
+			fmt.Printf("%s\n", strings.Join(execution.ActionIDs, ", "))
+		} else {
+			fmt.Printf("%s, ... and %d more\n", strings.Join(execution.ActionIDs[:3], ", "), len(execution.ActionIDs)-3)
+		}
+	}
+
+	// Statistics detail
+	if execution.Statistics != nil {
+		fmt.Printf("\nDetailed Statistics:\n")
+		fmt.Printf("  Assets Attacked:     %d\n", execution.Statistics.AssetsAttacked)
+		fmt.Printf("  Attack Success Rate: %.1f%%\n", execution.Statistics.AttackSuccess*100)
+		fmt.Printf("  Files Exfiltrated:   %d\n", execution.Statistics.FilesExfiltrated)
+		fmt.Printf("  Total Steps:         %d\n", execution.Statistics.TotalSteps)
+		if len(execution.Statistics.PlatformsAttacked) > 0 {
+			fmt.Printf("  Platforms Attacked:  %s\n", strings.Join(execution.Statistics.PlatformsAttacked, ", "))
+		}
+	}
+}
+
+func printComparisonJSON(comparison pkgExecutions.Comparison) error {
+	jsonData, err := json.MarshalIndent(comparison, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// printComparisonTable prints a summary of the comparison followed by a row per step,
+// showing changed fields as "before -> after"
+func printComparisonTable(comparison pkgExecutions.Comparison) {
+	fmt.Printf("Comparing %s (base) to %s\n", comparison.BaseID, comparison.TargetID)
+	fmt.Printf("Regressions: %d, Improvements: %d, Changed: %d, Added: %d, Removed: %d, Unchanged: %d\n\n",
+		comparison.Regressions, comparison.Improvements, comparison.Changed,
+		comparison.Added, comparison.Removed, comparison.Unchanged)
+
+	if len(comparison.Steps) == 0 {
+		fmt.Println("No steps to compare.")
+		return
+	}
+
+	tbl := table.New("Result", "Change", "Hostname", "Action", "Name", "Success", "Detected", "Logged", "Severity", "Correlations")
+
+	for _, step := range comparison.Steps {
+		result := ""
+		switch {
+		case step.Regression:
+			result = "REGRESSION"
+		case step.Improvement:
+			result = "improved"
+		}
+
+		// Show the current value of unchanged fields and "before -> after" for changed ones
+		current := step.After
+		if current == nil {
+			current = step.Before
+		}
+		values := map[string]string{
+			pkgExecutions.FieldSuccess:      formatOptionalBool(current.Success),
+			pkgExecutions.FieldDetected:     formatOptionalBool(current.Detected),
+			pkgExecutions.FieldLogged:       formatOptionalBool(current.Logged),
+			pkgExecutions.FieldSeverity:     valueOrDefault(current.Severity, "N/A"),
+			pkgExecutions.FieldCorrelations: fmt.Sprintf("%d", len(current.Correlations)),
+		}
+		for _, field := range step.Fields {
+			values[field.Field] = fmt.Sprintf("%s -> %s", valueOrDefault(field.Before, "N/A"), valueOrDefault(field.After, "N/A"))
+		}
+
+		tbl.AddRow(
+			result,
+			step.Change,
+			valueOrDefault(step.Hostname, step.AssetID),
+			valueOrDefault(step.ActionID, "N/A"),
+			step.Name,
+			values[pkgExecutions.FieldSuccess],
+			values[pkgExecutions.FieldDetected],
+			values[pkgExecutions.FieldLogged],
+			values[pkgExecutions.FieldSeverity],
+			values[pkgExecutions.FieldCorrelations],
+		)
+	}
+
+	// Print the table to stdout
+	tbl.Print()
+}
+
+// formatOptionalBool formats an optional bool, with unset values as N/A
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%t", *b)
+}
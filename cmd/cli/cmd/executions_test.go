@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// addExecutions seeds srv with two finished executions created an hour apart
+func addExecutions(srv *fakeserver.Server) {
+	older := time.Now().UTC().Add(-2 * time.Hour)
+	newer := older.Add(time.Hour)
+	srv.AddExecution(models.GetExecutionResponse{ID: "e1", AttackName: "Discovery", Status: models.StatusFinished, StatusState: models.StatusFinished, CreatedAt: &older})
+	srv.AddExecution(models.GetExecutionResponse{ID: "e2", AttackName: "Ransomware", Status: models.StatusFinished, StatusState: models.StatusFinished, CreatedAt: &newer})
+}
+
+func TestExecutionsList(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	addExecutions(srv)
+
+	out, err := runCLI(t, srv, "executions", "list", "--format", "json", "--order-by", "attack_name:asc")
+	if err != nil {
+		t.Fatalf("executions list error = %v", err)
+	}
+
+	var list models.ListWithCountExecutions
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if list.Count != 2 || len(list.Data) != 2 || list.Data[0].ID != "e1" || list.Data[1].ID != "e2" {
+		t.Errorf("executions list = %+v, want e1 then e2", list)
+	}
+
+	// Filters are passed through to the server
+	out, err = runCLI(t, srv, "executions", "list", "--format", "json", "--name", "ransom")
+	if err != nil {
+		t.Fatalf("executions list --name error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil || len(list.Data) != 1 || list.Data[0].ID != "e2" {
+		t.Errorf("executions list --name = %s, want e2 only", out)
+	}
+}
+
+func TestExecutionsListUnknownFilter(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	_, err := runCLI(t, srv, "executions", "list", "--filter", "colour=red")
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Errorf("executions list --filter error = %v, want a 400 from the server", err)
+	}
+}
+
+func TestExecutionsGet(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	addExecutions(srv)
+
+	out, err := runCLI(t, srv, "executions", "get", "e2")
+	if err != nil {
+		t.Fatalf("executions get error = %v", err)
+	}
+	if !strings.Contains(out, "ID:               e2") || !strings.Contains(out, "Ransomware") {
+		t.Errorf("executions get output = %s", out)
+	}
+
+	if _, err := runCLI(t, srv, "executions", "get", "missing"); err == nil || err.Error() != "execution not found: missing" {
+		t.Errorf("executions get of a missing execution error = %v", err)
+	}
+}
+
+func TestExecutionsListDateFlags(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	addExecutions(srv)
+
+	after := time.Now().UTC().Add(-90 * time.Minute).Format(time.RFC3339)
+	out, err := runCLI(t, srv, "executions", "list", "--format", "json", "--date-after", after)
+	if err != nil {
+		t.Fatalf("executions list --date-after error = %v", err)
+	}
+	var list models.ListWithCountExecutions
+	if err := json.Unmarshal([]byte(out), &list); err != nil || len(list.Data) != 1 || list.Data[0].ID != "e2" {
+		t.Errorf("executions list --date-after = %s, want e2 only", out)
+	}
+
+	if _, err := runCLI(t, srv, "executions", "list", "--date-before", "yesterday"); err == nil || !strings.HasPrefix(err.Error(), "invalid date-before format") {
+		t.Errorf("executions list with an invalid date error = %v", err)
+	}
+}
@@ -1,102 +1,207 @@
-package cmd
-
-import (
-	"context"
-	"fmt"
-	"os"
-	"strings"
-
-	"github.com/fourcorelabs/attack-sdk-go/pkg/config"
-	"github.com/spf13/cobra"
-)
-
-var (
-	// These will hold the resolved values after considering flags, env vars, and config file
-	cfg        config.Config
-	apiKeyVal  string
-	baseUrlVal string
-)
-
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:     "fourcore-cli",
-	Version: "1.0.0",
-	Short:   "CLI for FourCore ATTACK REST API",
-	Long: `A command-line interface to interact with the FourCore ATTACK REST API,
-allowing management and retrieval of various resources.`,
-	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Load config from file first
-		loadedCfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config file: %w", err)
-		}
-		cfg = loadedCfg // Store loaded config
-
-		// Determine effective API Key: Flag > Env Var > Config File
-		apiKeyFromFlag, _ := cmd.Flags().GetString("api-key")
-		apiKeyFromEnv := os.Getenv("FOURCORE_API_KEY")
-
-		if apiKeyFromFlag != "" {
-			apiKeyVal = apiKeyFromFlag
-		} else if apiKeyFromEnv != "" {
-			apiKeyVal = apiKeyFromEnv
-		} else {
-			apiKeyVal = cfg.APIKey // Use from loaded config
-		}
-
-		// Determine effective Base URL: Flag > Env Var > Config File > Default
-		baseUrlFromFlag, _ := cmd.Flags().GetString("base-url")
-		baseUrlFromEnv := os.Getenv("FOURCORE_BASE_URL")
-
-		if baseUrlFromFlag != "" {
-			baseUrlVal = baseUrlFromFlag
-		} else if baseUrlFromEnv != "" {
-			baseUrlVal = baseUrlFromEnv
-		} else if cfg.BaseURL != "" {
-			baseUrlVal = cfg.BaseURL // Use from loaded config
-		} else {
-			baseUrlVal = "https://prod.fourcore.io" // Default
-		}
-
-		// Update the global cfg struct *if* flags/env were used,
-		// so subcommands using it directly (like config view) see the effective values
-		cfg.APIKey = apiKeyVal
-		cfg.BaseURL = baseUrlVal
-
-		// Optional: You could store the resolved values in the command's context
-		// ctx := context.WithValue(cmd.Context(), configKey{}, cfg)
-		// cmd.SetContext(ctx) // Requires defining a configKey type
-
-		return nil
-	},
-}
-
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() error {
-	// Set version template
-	rootCmd.SetVersionTemplate(`{{printf "%s %s\n" .Name .Version}}`)
-	return rootCmd.ExecuteContext(context.Background())
-}
-
-func init() {
-	// Define persistent flags valid for all subcommands
-	rootCmd.PersistentFlags().StringP("api-key", "k", "", "API Key for authentication (env: FOURCORE_API_KEY)")
-	rootCmd.PersistentFlags().StringP("base-url", "u", "", "Base URL for the API (env: FOURCORE_BASE_URL)")
-
-	// Add subcommands (will be done in their respective files, e.g., config.go, audit.go)
-	// Example: addConfigCmd()
-	// Example: addAuditCmd()
-}
-
-// Helper function (can be moved to a utils file later)
-func maskString(s string) string {
-	if s == "" {
-		return "<not set>"
-	}
-	if len(s) <= 8 {
-		return strings.Repeat("*", len(s))
-	}
-	// Show first 4 and last 4 characters
-	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
-}
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/config"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// These will hold the resolved values after considering flags, env vars, and config file
+	cfg        config.Config
+	apiKeyVal  string
+	baseUrlVal string
+	debugVal   bool
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:     "fourcore-cli",
+	Version: "1.0.0",
+	Short:   "CLI for FourCore ATTACK REST API",
+	Long: `A command-line interface to interact with the FourCore ATTACK REST API,
+allowing management and retrieval of various resources.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Load config from file first
+		loadedCfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config file: %w", err)
+		}
+		cfg = loadedCfg // Store loaded config
+
+		// Determine effective API Key: Flag > Env Var > Config File
+		apiKeyFromFlag, _ := cmd.Flags().GetString("api-key")
+		apiKeyFromEnv := os.Getenv("FOURCORE_API_KEY")
+
+		if apiKeyFromFlag != "" {
+			apiKeyVal = apiKeyFromFlag
+		} else if apiKeyFromEnv != "" {
+			apiKeyVal = apiKeyFromEnv
+		} else {
+			apiKeyVal = cfg.APIKey // Use from loaded config
+		}
+
+		// Determine effective Base URL: Flag > Env Var > Config File > Default
+		baseUrlFromFlag, _ := cmd.Flags().GetString("base-url")
+		baseUrlFromEnv := os.Getenv("FOURCORE_BASE_URL")
+
+		if baseUrlFromFlag != "" {
+			baseUrlVal = baseUrlFromFlag
+		} else if baseUrlFromEnv != "" {
+			baseUrlVal = baseUrlFromEnv
+		} else if cfg.BaseURL != "" {
+			baseUrlVal = cfg.BaseURL // Use from loaded config
+		} else {
+			baseUrlVal = "https://prod.fourcore.io" // Default
+		}
+
+		// Update the global cfg struct *if* flags/env were used,
+		// so subcommands using it directly (like config view) see the effective values
+		cfg.APIKey = apiKeyVal
+		cfg.BaseURL = baseUrlVal
+
+		debugVal, _ = cmd.Flags().GetBool("debug")
+
+		// Optional: You could store the resolved values in the command's context
+		// ctx := context.WithValue(cmd.Context(), configKey{}, cfg)
+		// cmd.SetContext(ctx) // Requires defining a configKey type
+
+		return nil
+	},
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() error {
+	// Set version template
+	rootCmd.SetVersionTemplate(`{{printf "%s %s\n" .Name .Version}}`)
+
+	// Cancel in-flight requests and rate limit waits on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
+}
+
+func init() {
+	// Define persistent flags valid for all subcommands
+	rootCmd.PersistentFlags().StringP("api-key", "k", "", "API Key for authentication (env: FOURCORE_API_KEY)")
+	rootCmd.PersistentFlags().StringP("base-url", "u", "", "Base URL for the API (env: FOURCORE_BASE_URL)")
+	rootCmd.PersistentFlags().Bool("debug", false, "Log API requests and responses to stderr (secrets are redacted)")
+
+	// Add subcommands (will be done in their respective files, e.g., config.go, audit.go)
+	// Example: addConfigCmd()
+	// Example: addAuditCmd()
+}
+
+// newAPIClient creates an API client from the resolved API key, base URL and global flags
+func newAPIClient() (*api.HTTPAPI, error) {
+	opts := []api.Option{
+		api.WithUserAgent(fmt.Sprintf("fourcore-cli/%s", rootCmd.Version)),
+	}
+
+	if debugVal {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		opts = append(opts, api.WithLogger(logger))
+	}
+
+	return api.New(baseUrlVal, apiKeyVal, opts...)
+}
+
+// Helper function (can be moved to a utils file later)
+func maskString(s string) string {
+	if s == "" {
+		return "<not set>"
+	}
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	// Show first 4 and last 4 characters
+	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+}
+
+// addQueryFlags registers the --order-by and --filter flags shared by list commands
+func addQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("order-by", []string{}, "Order by column, as name[:asc|desc] (can be specified multiple times, in priority order)")
+	cmd.Flags().StringArray("filter", []string{}, "Filter by field, as name=value[,value...] (can be specified multiple times)")
+}
+
+// getQueryFlags parses the --order-by and --filter flags
+func getQueryFlags(cmd *cobra.Command) ([]models.OrderBy, []models.FilterBy, error) {
+	orderByStrs, _ := cmd.Flags().GetStringArray("order-by")
+	filterStrs, _ := cmd.Flags().GetStringArray("filter")
+
+	var orderBy []models.OrderBy
+	for _, s := range orderByStrs {
+		o, err := api.ParseOrderBy(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		orderBy = append(orderBy, o)
+	}
+
+	var filters []models.FilterBy
+	for _, s := range filterStrs {
+		f, err := api.ParseFilterBy(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	return orderBy, filters, nil
+}
+
+// getDateFlags parses the --date-after and --date-before flags
+func getDateFlags(cmd *cobra.Command) (dateAfter, dateBefore time.Time, err error) {
+	dateAfterStr, _ := cmd.Flags().GetString("date-after")
+	dateBeforeStr, _ := cmd.Flags().GetString("date-before")
+
+	if dateAfterStr != "" {
+		dateAfter, err = time.Parse(time.RFC3339, dateAfterStr)
+		if err != nil {
+			return dateAfter, dateBefore, fmt.Errorf("invalid date-after format, must be RFC3339 format (e.g., 2023-01-01T00:00:00Z): %w", err)
+		}
+	}
+	if dateBeforeStr != "" {
+		dateBefore, err = time.Parse(time.RFC3339, dateBeforeStr)
+		if err != nil {
+			return dateAfter, dateBefore, fmt.Errorf("invalid date-before format, must be RFC3339 format (e.g., 2023-01-01T00:00:00Z): %w", err)
+		}
+	}
+
+	return dateAfter, dateBefore, nil
+}
+
+// getStatusFlag parses and validates the --status flag, returning an empty status if unset
+func getStatusFlag(cmd *cobra.Command) (models.ExecutionStatus, error) {
+	statusStr, _ := cmd.Flags().GetString("status")
+	if statusStr == "" {
+		return "", nil
+	}
+	return models.ParseExecutionStatus(statusStr)
+}
+
+// newMarkdownRenderer returns a Markdown renderer, replacing the template called name with the
+// file given by the --template flag if set
+func newMarkdownRenderer(cmd *cobra.Command, name string) (*report.MarkdownRenderer, error) {
+	r := report.NewMarkdownRenderer()
+
+	templatePath, _ := cmd.Flags().GetString("template")
+	if templatePath != "" {
+		if err := r.OverrideFile(name, templatePath); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
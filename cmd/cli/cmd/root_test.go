@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runCLI runs the CLI with args against srv and returns what it wrote to stdout.
+// The config file is read from an empty home directory and every flag is reset afterwards.
+func runCLI(t *testing.T, srv *fakeserver.Server, args ...string) (string, error) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("FOURCORE_API_KEY", "")
+	t.Setenv("FOURCORE_BASE_URL", "")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	rootCmd.SetArgs(append(args, "--api-key", fakeserver.DefaultAPIKey, "--base-url", srv.URL))
+	err = rootCmd.ExecuteContext(context.Background())
+
+	w.Close()
+	os.Stdout = stdout
+	out := <-output
+
+	resetFlags(rootCmd)
+	return out, err
+}
+
+// resetFlags restores the default value of every flag of cmd and its subcommands, as cobra keeps
+// flag values between executions
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
@@ -13,6 +13,8 @@ const EndpointActionsV2URI = "/api/v2/actions"
 
 // ExecuteEndpointChain executes an endpoint attack chain by chain ID on specified assets
 func ExecuteEndpointAction(ctx context.Context, h *api.HTTPAPI, attackRun models.AttackRunActionsStagers) (models.GetExecutionResponse, error) {
+	ctx = api.WithOperation(ctx, "actions.ExecuteEndpointAction")
+
 	var response models.GetExecutionResponse
 
 	endpoint := fmt.Sprintf("%s/run", EndpointActionsV2URI)
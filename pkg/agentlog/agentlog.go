@@ -1,68 +1,67 @@
-package agentlog
-
-import (
-	"context"
-	"strconv"
-	"strings"
-	"time"
-
-	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
-)
-
-// AgentLogV2URI is the endpoint for the agent logs API
-const AgentLogV2URI = "/api/v2/agent_logs"
-
-// AgentLogOpts represents options for listing agent logs
-type AgentLogOpts struct {
-	Size       int       `json:"size"`
-	Offset     int       `json:"offset"`
-	Order      string    `json:"order"`
-	AssetIDs   []string  `json:"asset_id,omitempty"`
-	Action     string    `json:"action,omitempty"`
-	DateAfter  time.Time `json:"date_after,omitempty"`
-	DateBefore time.Time `json:"date_before,omitempty"`
-	Query      string    `json:"query,omitempty"`
-}
-
-// GetAgentLogs retrieves agent logs from the API with the given options
-func GetAgentLogs(ctx context.Context, h *api.HTTPAPI, opts AgentLogOpts) (models.PaginationResponse[agentlog.AgentLog], error) {
-	var resp models.PaginationResponse[agentlog.AgentLog]
-
-	// Prepare parameters map
-	params := map[string]string{
-		"size":   strconv.FormatInt(int64(opts.Size), 10),
-		"offset": strconv.FormatInt(int64(opts.Offset), 10),
-		"order":  opts.Order,
-	}
-
-	// Add optional filter params if set
-	if opts.Action != "" {
-		params["action"] = opts.Action
-	}
-
-	if !opts.DateAfter.IsZero() {
-		params["date_after"] = opts.DateAfter.Format(time.RFC3339)
-	}
-
-	if !opts.DateBefore.IsZero() {
-		params["date_before"] = opts.DateBefore.Format(time.RFC3339)
-	}
-
-	if opts.Query != "" {
-		params["q"] = opts.Query
-	}
-
-	// Add asset_id if there are any in the list
-	if len(opts.AssetIDs) > 0 {
-		params["asset_id"] = strings.Join(opts.AssetIDs, ",")
-	}
-
-	// Make the API request
-	_, err := h.GetJSON(ctx, AgentLogV2URI, &resp, api.ReqOptions{
-		Params: params,
-	})
-
-	return resp, err
-}
+package agentlog
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
+)
+
+// AgentLogV2URI is the endpoint for the agent logs API
+const AgentLogV2URI = "/api/v2/agent_logs"
+
+// AgentLogOpts represents options for listing agent logs
+type AgentLogOpts struct {
+	Size       int       `json:"size"`
+	Offset     int       `json:"offset"`
+	Order      string    `json:"order"`
+	AssetIDs   []string  `json:"asset_id,omitempty"`
+	Action     string    `json:"action,omitempty"`
+	DateAfter  time.Time `json:"date_after,omitempty"`
+	DateBefore time.Time `json:"date_before,omitempty"`
+	Query      string    `json:"query,omitempty"`
+
+	OrderBy []models.OrderBy  `json:"order_by,omitempty"` // Columns to order by, in priority order
+	Filters []models.FilterBy `json:"-"`                  // Additional filters, overriding the ones above
+}
+
+// GetAgentLogs retrieves agent logs from the API with the given options
+func GetAgentLogs(ctx context.Context, h *api.HTTPAPI, opts AgentLogOpts) (models.PaginationResponse[agentlog.AgentLog], error) {
+	ctx = api.WithOperation(ctx, "agentlog.GetAgentLogs")
+
+	var resp models.PaginationResponse[agentlog.AgentLog]
+
+	params := api.NewQuery().
+		Page(opts.Size, opts.Offset, opts.Order).
+		Set("action", opts.Action).
+		SetTime("date_after", opts.DateAfter).
+		SetTime("date_before", opts.DateBefore).
+		Set("q", opts.Query).
+		SetList("asset_id", opts.AssetIDs).
+		OrderBy(opts.OrderBy...).
+		Filter(opts.Filters...)
+
+	// Make the API request
+	_, err := h.GetJSON(ctx, AgentLogV2URI, &resp, api.ReqOptions{
+		Params: params,
+	})
+
+	return resp, err
+}
+
+// All returns an iterator over every agent log matching opts, starting at opts.Offset and
+// fetching opts.Size logs per request
+func All(ctx context.Context, h *api.HTTPAPI, opts AgentLogOpts, pageOpts ...api.PageOption) iter.Seq2[agentlog.AgentLog, error] {
+	fetch := func(ctx context.Context, offset, size int) ([]agentlog.AgentLog, int, error) {
+		page := opts
+		page.Offset, page.Size = offset, size
+
+		resp, err := GetAgentLogs(ctx, h, page)
+		return resp.Data, resp.TotalRows, err
+	}
+
+	return api.Paginate(ctx, opts.Offset, opts.Size, fetch, pageOpts...)
+}
@@ -0,0 +1,40 @@
+// Package analysis aggregates the results of many executions to measure the detection coverage
+// of the security stack over time
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// FetchReports retrieves the detailed report of every execution matching opts, such as the executions
+// created in a date range. Executions are listed with executions.All, so opts.Size is the page size.
+func FetchReports(ctx context.Context, h *api.HTTPAPI, opts executions.ExecutionOpts) ([]models.GetExecutionResponse, error) {
+	var reports []models.GetExecutionResponse
+
+	for e, err := range executions.All(ctx, h, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list executions: %w", err)
+		}
+
+		report, err := executions.GetExecutionReport(ctx, h, e.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve execution report %s: %w", e.ID, err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// percent returns n as a percentage of total, or 0 if total is 0
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
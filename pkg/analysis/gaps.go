@@ -0,0 +1,240 @@
+package analysis
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/report"
+)
+
+// Dimension is an attribute steps are grouped by in a gap analysis
+type Dimension string
+
+// Dimensions of a gap analysis
+const (
+	ByAction    Dimension = "action"    // Grouped by action ID
+	ByTechnique Dimension = "technique" // Grouped by MITRE ATT&CK technique of the action
+	ByPlatform  Dimension = "platform"  // Grouped by asset platform
+	ByAsset     Dimension = "asset"     // Grouped by asset ID
+)
+
+// Dimensions lists every dimension of a gap analysis
+var Dimensions = []Dimension{ByAction, ByTechnique, ByPlatform, ByAsset}
+
+// ParseDimension parses a dimension, case-insensitively
+func ParseDimension(s string) (Dimension, error) {
+	d := Dimension(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Dimensions, d) {
+		return "", fmt.Errorf("invalid dimension '%s': must be one of %s", s, strings.Join(models.EnumStrings(Dimensions), ", "))
+	}
+	return d, nil
+}
+
+// Gap is the detection coverage of a group of steps, such as every run of an action.
+// Rates are percentages of the steps that succeeded, except SuccessRate.
+type Gap struct {
+	Dimension     Dimension `json:"dimension"`
+	Key           string    `json:"key"`            // Action ID, technique ID, platform or asset ID
+	Name          string    `json:"name,omitempty"` // Step name, technique tactics or asset hostname
+	Executions    int       `json:"executions"`     // Number of executions with a step in the group
+	Steps         int       `json:"steps"`
+	Finished      int       `json:"finished"`   // Steps with a result
+	Succeeded     int       `json:"succeeded"`  // Steps whose attack succeeded
+	Detected      int       `json:"detected"`   // Succeeded steps that were detected
+	Logged        int       `json:"logged"`     // Succeeded steps that were logged
+	Alerted       int       `json:"alerted"`    // Succeeded steps with at least one correlated alert
+	Undetected    int       `json:"undetected"` // Succeeded steps that were not detected
+	SuccessRate   float64   `json:"success_rate"`
+	DetectionRate float64   `json:"detection_rate"`
+	LoggingRate   float64   `json:"logging_rate"`
+	AlertRate     float64   `json:"alert_rate"`
+}
+
+// NeverDetected reports whether steps of the group succeeded but none of them was detected
+func (g Gap) NeverDetected() bool {
+	return g.Succeeded > 0 && g.Detected == 0
+}
+
+// GapReport is the detection coverage of a set of executions along every dimension,
+// each ranked from the worst gap to the best covered group
+type GapReport struct {
+	Executions int   `json:"executions"`
+	Steps      int   `json:"steps"`
+	Unmapped   int   `json:"unmapped"` // Steps whose action maps to no MITRE ATT&CK technique
+	Actions    []Gap `json:"actions"`
+	Techniques []Gap `json:"techniques"`
+	Platforms  []Gap `json:"platforms"`
+	Assets     []Gap `json:"assets"`
+}
+
+// By returns the ranked groups of a dimension
+func (r GapReport) By(d Dimension) []Gap {
+	switch d {
+	case ByAction:
+		return r.Actions
+	case ByTechnique:
+		return r.Techniques
+	case ByPlatform:
+		return r.Platforms
+	case ByAsset:
+		return r.Assets
+	default:
+		return nil
+	}
+}
+
+// Gaps aggregates the steps of executions, including nested action steps, by action, MITRE ATT&CK
+// technique, platform and asset. Actions are mapped to techniques with techniques, and the technique
+// dimension is empty if it is nil. Groups are ranked by ascending detection rate, then by descending
+// number of undetected steps, so the groups whose successful attacks are never detected come first.
+func Gaps(reports []models.GetExecutionResponse, techniques report.TechniqueMap) GapReport {
+	r := GapReport{Executions: len(reports)}
+	groups := map[Dimension]*gapGroups{}
+	for _, d := range Dimensions {
+		groups[d] = &gapGroups{dimension: d, index: map[string]int{}}
+	}
+
+	for _, e := range reports {
+		for v := range executions.Steps(e) {
+			step := v.Step
+			r.Steps++
+
+			actionID := step.ActionID
+			if actionID == "" {
+				actionID = step.Name
+			}
+			groups[ByAction].add(actionID, step.Name, e.ID, step)
+			groups[ByPlatform].add(cmp.Or(v.Asset.Platform, "unknown"), "", e.ID, step)
+			groups[ByAsset].add(v.Asset.AssetID, v.Asset.Hostname, e.ID, step)
+
+			mapped := techniques.Lookup(step.ActionID)
+			if len(mapped) == 0 {
+				r.Unmapped++
+			}
+			for _, t := range mapped {
+				groups[ByTechnique].add(t.ID, strings.Join(t.Tactics, ", "), e.ID, step)
+			}
+		}
+	}
+
+	r.Actions = groups[ByAction].ranked()
+	r.Techniques = groups[ByTechnique].ranked()
+	r.Platforms = groups[ByPlatform].ranked()
+	r.Assets = groups[ByAsset].ranked()
+	return r
+}
+
+// WriteGapsCSV writes groups to w as CSV with a header row
+func WriteGapsCSV(w io.Writer, gaps []Gap) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"dimension", "key", "name", "executions", "steps", "finished", "succeeded", "detected", "logged", "alerted", "undetected",
+		"success_rate", "detection_rate", "logging_rate", "alert_rate",
+	}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, g := range gaps {
+		if err := cw.Write([]string{
+			string(g.Dimension), g.Key, g.Name,
+			strconv.Itoa(g.Executions), strconv.Itoa(g.Steps), strconv.Itoa(g.Finished), strconv.Itoa(g.Succeeded),
+			strconv.Itoa(g.Detected), strconv.Itoa(g.Logged), strconv.Itoa(g.Alerted), strconv.Itoa(g.Undetected),
+			formatRate(g.SuccessRate), formatRate(g.DetectionRate), formatRate(g.LoggingRate), formatRate(g.AlertRate),
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// gapGroups accumulates the groups of a dimension in the order they are first seen
+type gapGroups struct {
+	dimension  Dimension
+	gaps       []Gap
+	executions []map[string]struct{}
+	index      map[string]int
+}
+
+// add counts a step of execution executionID in the group key
+func (g *gapGroups) add(key, name, executionID string, step *models.GetExecutionResponseAssetStep) {
+	i, ok := g.index[key]
+	if !ok {
+		i = len(g.gaps)
+		g.index[key] = i
+		g.gaps = append(g.gaps, Gap{Dimension: g.dimension, Key: key})
+		g.executions = append(g.executions, map[string]struct{}{})
+	}
+
+	gap := &g.gaps[i]
+	if gap.Name == "" {
+		gap.Name = name
+	}
+	g.executions[i][executionID] = struct{}{}
+
+	gap.Steps++
+	if step.Success == nil {
+		return
+	}
+	gap.Finished++
+	if !*step.Success {
+		return
+	}
+	gap.Succeeded++
+	if models.IsTrue(step.Detected) {
+		gap.Detected++
+	} else {
+		gap.Undetected++
+	}
+	if models.IsTrue(step.Logged) {
+		gap.Logged++
+	}
+	if len(step.Correlations) > 0 {
+		gap.Alerted++
+	}
+}
+
+// ranked computes the rates of the groups and sorts them from the worst gap to the best covered group.
+// Groups without successful steps have no gap and come last.
+func (g *gapGroups) ranked() []Gap {
+	gaps := make([]Gap, len(g.gaps))
+	for i, gap := range g.gaps {
+		gap.Executions = len(g.executions[i])
+		gap.SuccessRate = percent(gap.Succeeded, gap.Finished)
+		gap.DetectionRate = percent(gap.Detected, gap.Succeeded)
+		gap.LoggingRate = percent(gap.Logged, gap.Succeeded)
+		gap.AlertRate = percent(gap.Alerted, gap.Succeeded)
+		gaps[i] = gap
+	}
+
+	slices.SortStableFunc(gaps, func(a, b Gap) int {
+		if (a.Succeeded == 0) != (b.Succeeded == 0) {
+			if a.Succeeded == 0 {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(
+			cmp.Compare(a.DetectionRate, b.DetectionRate),
+			cmp.Compare(b.Undetected, a.Undetected),
+			strings.Compare(a.Key, b.Key),
+		)
+	})
+	return gaps
+}
+
+// formatRate formats a percentage with one decimal
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 1, 64)
+}
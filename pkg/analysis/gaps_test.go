@@ -0,0 +1,167 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/report"
+)
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// gapStep returns a step of actionID with the given result, nil meaning no result yet
+func gapStep(actionID string, success, detected *bool) models.GetExecutionResponseAssetStep {
+	return models.GetExecutionResponseAssetStep{ActionID: actionID, Name: "Step " + actionID, Success: success, Detected: detected}
+}
+
+// gapReports returns two executions on a windows and a linux asset
+func gapReports() []models.GetExecutionResponse {
+	yes, no := boolPtr(true), boolPtr(false)
+	return []models.GetExecutionResponse{
+		{
+			ID: "e1",
+			Assets: []models.AssetExecutionDetails{{
+				AssetID: "a1", Hostname: "host-1", Platform: "windows",
+				Steps: []models.GetExecutionResponseAssetStep{
+					gapStep("never", yes, no),
+					gapStep("half", yes, yes),
+					gapStep("always", yes, yes),
+					gapStep("prevented", no, nil),
+					{
+						ActionID: "composite", Success: yes, Detected: yes,
+						ActionSteps: []models.GetExecutionResponseAssetStep{gapStep("never", yes, nil)},
+					},
+				},
+			}},
+		},
+		{
+			ID: "e2",
+			Assets: []models.AssetExecutionDetails{{
+				AssetID: "a2", Hostname: "host-2", Platform: "linux",
+				Steps: []models.GetExecutionResponseAssetStep{
+					gapStep("half", yes, no),
+					gapStep("pending", nil, nil),
+				},
+			}},
+		},
+	}
+}
+
+func TestGaps(t *testing.T) {
+	r := Gaps(gapReports(), report.TechniqueMap{
+		"never": {{ID: "T1003", Tactics: []string{"Credential Access"}}},
+		"half":  {{ID: "T1003", Tactics: []string{"Credential Access"}}},
+	})
+
+	if r.Executions != 2 || r.Steps != 8 || r.Unmapped != 4 {
+		t.Errorf("Gaps() = %d executions, %d steps, %d unmapped, want 2, 8 and 4", r.Executions, r.Steps, r.Unmapped)
+	}
+
+	// Ranked by detection rate, then by undetected steps, then by key, with groups without successful steps last
+	want := []struct {
+		key           string
+		steps         int
+		undetected    int
+		detectionRate float64
+		neverDetected bool
+	}{
+		{"never", 2, 2, 0, true},
+		{"half", 2, 1, 50, false},
+		{"always", 1, 0, 100, false},
+		{"composite", 1, 0, 100, false},
+		{"pending", 1, 0, 0, false},
+		{"prevented", 1, 0, 0, false},
+	}
+	if len(r.Actions) != len(want) {
+		t.Fatalf("Gaps() = %d actions, want %d", len(r.Actions), len(want))
+	}
+	for i, w := range want {
+		g := r.Actions[i]
+		if g.Key != w.key || g.Steps != w.steps || g.Undetected != w.undetected || g.DetectionRate != w.detectionRate {
+			t.Errorf("action %d = %s with %d steps, %d undetected and %.1f%% detected, want %s with %d, %d and %.1f%%",
+				i, g.Key, g.Steps, g.Undetected, g.DetectionRate, w.key, w.steps, w.undetected, w.detectionRate)
+		}
+		if g.NeverDetected() != w.neverDetected {
+			t.Errorf("action %s NeverDetected() = %t, want %t", g.Key, g.NeverDetected(), w.neverDetected)
+		}
+	}
+
+	if g := r.Actions[1]; g.Executions != 2 || g.Name != "Step half" || g.SuccessRate != 100 {
+		t.Errorf("half = %+v, want 2 executions and a 100%% success rate", g)
+	}
+
+	techniques := r.By(ByTechnique)
+	if len(techniques) != 1 || techniques[0].Key != "T1003" || techniques[0].Steps != 4 || techniques[0].DetectionRate != 25 {
+		t.Errorf("techniques = %+v, want T1003 with 4 steps detected at 25%%", techniques)
+	}
+
+	platforms := r.By(ByPlatform)
+	if len(platforms) != 2 || platforms[0].Key != "linux" || platforms[1].Key != "windows" {
+		t.Errorf("platforms = %+v, want linux before windows", platforms)
+	}
+	if assets := r.By(ByAsset); len(assets) != 2 || assets[0].Name != "host-2" {
+		t.Errorf("assets = %+v, want host-2 first", assets)
+	}
+}
+
+func TestGapsWithoutTechniques(t *testing.T) {
+	r := Gaps(gapReports(), nil)
+	if len(r.Techniques) != 0 || r.Unmapped != r.Steps {
+		t.Errorf("Gaps() without techniques = %d techniques and %d unmapped of %d steps", len(r.Techniques), r.Unmapped, r.Steps)
+	}
+}
+
+func TestNeverDetected(t *testing.T) {
+	tests := []struct {
+		name string
+		gap  Gap
+		want bool
+	}{
+		{"succeeded and undetected", Gap{Succeeded: 2, Undetected: 2}, true},
+		{"detected once", Gap{Succeeded: 2, Detected: 1, Undetected: 1}, false},
+		{"only prevented", Gap{Steps: 2, Finished: 2}, false},
+		{"no steps", Gap{}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.gap.NeverDetected(); got != tt.want {
+			t.Errorf("%s: NeverDetected() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteGapsCSV(t *testing.T) {
+	gaps := Gaps(gapReports(), nil).Actions
+
+	var buf bytes.Buffer
+	if err := WriteGapsCSV(&buf, gaps); err != nil {
+		t.Fatalf("WriteGapsCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	if len(rows) != len(gaps)+1 || rows[0][1] != "key" {
+		t.Fatalf("WriteGapsCSV() = %d rows, want a header and %d groups", len(rows), len(gaps))
+	}
+	if got := rows[2]; got[0] != "action" || got[1] != "half" || got[4] != "2" || got[12] != "50.0" {
+		t.Errorf("half row = %v", got)
+	}
+
+	if err := WriteGapsCSV(failingWriter{}, gaps); err == nil {
+		t.Error("WriteGapsCSV() to a failing writer succeeded")
+	}
+}
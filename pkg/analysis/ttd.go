@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// StepTTD is the time a detection took to fire for a step, measured from the creation of the step to the
+// detection time of its earliest correlated alert
+type StepTTD struct {
+	ExecutionID     string        `json:"execution_id"`
+	AssetID         string        `json:"asset_id,omitempty"`
+	Hostname        string        `json:"hostname,omitempty"`
+	ActionID        string        `json:"action_id,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	IntegrationType string        `json:"integration_type"` // Integration of the alert, or its source if unset
+	AlertName       string        `json:"alert_name,omitempty"`
+	StartedAt       time.Time     `json:"started_at"`
+	DetectedAt      time.Time     `json:"detected_at"`
+	TTD             time.Duration `json:"ttd_ns"`
+}
+
+// TTDStats are statistics of a set of time-to-detect measurements
+type TTDStats struct {
+	Count  int           `json:"count"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	Max    time.Duration `json:"max_ns"`
+	Mean   time.Duration `json:"mean_ns"`
+}
+
+// GroupTTD is the time-to-detect statistics of a group of steps, such as every run of an action
+type GroupTTD struct {
+	Key  string `json:"key"`            // Action ID, integration type or execution ID
+	Name string `json:"name,omitempty"` // Step name or attack name
+	TTDStats
+}
+
+// TTDOpts represents options for computing time-to-detect statistics
+type TTDOpts struct {
+	// OutlierThreshold is the time-to-detect above which a step is an outlier. If zero, steps above
+	// the upper Tukey fence of all measurements, Q3 + 1.5 × IQR, are outliers.
+	OutlierThreshold time.Duration
+}
+
+// TTDReport is the time-to-detect statistics of a set of executions. Groups are sorted by descending
+// p95, so the slowest detections come first.
+type TTDReport struct {
+	Overall          TTDStats      `json:"overall"`
+	Undetected       int           `json:"undetected"` // Succeeded steps without a correlated alert
+	Unmeasured       int           `json:"unmeasured"` // Steps with correlated alerts none of which could be measured
+	Steps            []StepTTD     `json:"steps"`      // Measurement of each detected step, using its earliest alert
+	Actions          []GroupTTD    `json:"actions"`
+	Integrations     []GroupTTD    `json:"integrations"` // Measured with the earliest alert of each integration per step
+	Executions       []GroupTTD    `json:"executions"`
+	OutlierThreshold time.Duration `json:"outlier_threshold_ns"`
+	Outliers         []StepTTD     `json:"outliers"` // Steps above OutlierThreshold, slowest first
+}
+
+// TimeToDetect computes how long the security stack took to raise an alert for the steps of executions,
+// including nested action steps. A step is measured from its creation, or its last update if unset, to the
+// detection time of its correlations. Correlations without a detection time, or detected before the step
+// started, are not measured. Steps with correlations but no measurement, including steps without a creation
+// or update time, are counted as unmeasured rather than undetected.
+func TimeToDetect(reports []models.GetExecutionResponse, opts TTDOpts) TTDReport {
+	var r TTDReport
+	actions := newTTDGroups()
+	integrations := newTTDGroups()
+	execs := newTTDGroups()
+
+	for _, e := range reports {
+		for v := range executions.Steps(e) {
+			step := v.Step
+			start := step.CreatedAt
+			if start == nil {
+				start = step.UpdatedAt
+			}
+
+			// Earliest alert of each integration
+			var earliest []StepTTD
+			if start != nil {
+				for _, c := range step.Correlations {
+					if c.DetectionTime.IsZero() || c.DetectionTime.Before(*start) {
+						continue
+					}
+
+					m := StepTTD{
+						ExecutionID:     e.ID,
+						AssetID:         v.Asset.AssetID,
+						Hostname:        v.Asset.Hostname,
+						ActionID:        cmp.Or(step.ActionID, step.Name),
+						Name:            step.Name,
+						IntegrationType: cmp.Or(c.IntegrationType, c.Source, "unknown"),
+						AlertName:       c.Name,
+						StartedAt:       *start,
+						DetectedAt:      c.DetectionTime,
+						TTD:             c.DetectionTime.Sub(*start),
+					}
+					i := slices.IndexFunc(earliest, func(o StepTTD) bool { return o.IntegrationType == m.IntegrationType })
+					switch {
+					case i < 0:
+						earliest = append(earliest, m)
+					case m.TTD < earliest[i].TTD:
+						earliest[i] = m
+					}
+				}
+			}
+
+			if len(earliest) == 0 {
+				switch {
+				case len(step.Correlations) > 0:
+					r.Unmeasured++
+				case models.IsTrue(step.Success):
+					r.Undetected++
+				}
+				continue
+			}
+
+			first := slices.MinFunc(earliest, func(a, b StepTTD) int { return cmp.Compare(a.TTD, b.TTD) })
+			r.Steps = append(r.Steps, first)
+			actions.add(first.ActionID, first.Name, first.TTD)
+			execs.add(e.ID, e.AttackName, first.TTD)
+			for _, m := range earliest {
+				integrations.add(m.IntegrationType, "", m.TTD)
+			}
+		}
+	}
+
+	all := make([]time.Duration, len(r.Steps))
+	for i, m := range r.Steps {
+		all[i] = m.TTD
+	}
+	r.Overall = NewTTDStats(all)
+	r.Actions = actions.stats()
+	r.Integrations = integrations.stats()
+	r.Executions = execs.stats()
+
+	r.OutlierThreshold = opts.OutlierThreshold
+	if r.OutlierThreshold == 0 && len(all) >= 4 {
+		slices.Sort(all)
+		q1, q3 := percentile(all, 25), percentile(all, 75)
+		r.OutlierThreshold = q3 + (q3-q1)*3/2
+	}
+	if r.OutlierThreshold > 0 {
+		for _, m := range r.Steps {
+			if m.TTD > r.OutlierThreshold {
+				r.Outliers = append(r.Outliers, m)
+			}
+		}
+		slices.SortStableFunc(r.Outliers, func(a, b StepTTD) int { return cmp.Compare(b.TTD, a.TTD) })
+	}
+
+	return r
+}
+
+// NewTTDStats computes the statistics of time-to-detect measurements. Percentiles use the nearest-rank
+// method and the median of an even number of measurements is the mean of the two middle ones.
+func NewTTDStats(ttds []time.Duration) TTDStats {
+	if len(ttds) == 0 {
+		return TTDStats{}
+	}
+
+	sorted := slices.Clone(ttds)
+	slices.Sort(sorted)
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	return TTDStats{
+		Count:  n,
+		Min:    sorted[0],
+		Median: median,
+		P95:    percentile(sorted, 95),
+		Max:    sorted[n-1],
+		Mean:   sum / time.Duration(n),
+	}
+}
+
+// percentile returns the p-th percentile of sorted measurements using the nearest-rank method
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// ttdGroups accumulates the measurements of groups in the order they are first seen
+type ttdGroups struct {
+	groups []GroupTTD
+	ttds   [][]time.Duration
+	index  map[string]int
+}
+
+func newTTDGroups() *ttdGroups {
+	return &ttdGroups{index: map[string]int{}}
+}
+
+// add records a measurement in the group key
+func (g *ttdGroups) add(key, name string, ttd time.Duration) {
+	i, ok := g.index[key]
+	if !ok {
+		i = len(g.groups)
+		g.index[key] = i
+		g.groups = append(g.groups, GroupTTD{Key: key})
+		g.ttds = append(g.ttds, nil)
+	}
+	if g.groups[i].Name == "" {
+		g.groups[i].Name = name
+	}
+	g.ttds[i] = append(g.ttds[i], ttd)
+}
+
+// stats computes the statistics of the groups, sorted by descending p95
+func (g *ttdGroups) stats() []GroupTTD {
+	groups := make([]GroupTTD, len(g.groups))
+	for i, group := range g.groups {
+		group.TTDStats = NewTTDStats(g.ttds[i])
+		groups[i] = group
+	}
+
+	slices.SortStableFunc(groups, func(a, b GroupTTD) int {
+		return cmp.Or(cmp.Compare(b.P95, a.P95), cmp.Compare(b.Median, a.Median), cmp.Compare(a.Key, b.Key))
+	})
+	return groups
+}
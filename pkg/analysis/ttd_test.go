@@ -0,0 +1,282 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// seconds converts whole seconds to durations
+func seconds(s ...int) []time.Duration {
+	d := make([]time.Duration, len(s))
+	for i, v := range s {
+		d[i] = time.Duration(v) * time.Second
+	}
+	return d
+}
+
+func TestNewTTDStats(t *testing.T) {
+	var oneToTwenty, oneToTwentyOne []int
+	for i := 1; i <= 21; i++ {
+		if i <= 20 {
+			oneToTwenty = append(oneToTwenty, i)
+		}
+		oneToTwentyOne = append(oneToTwentyOne, i)
+	}
+
+	tests := []struct {
+		name string
+		ttds []time.Duration
+		want TTDStats
+	}{
+		{
+			name: "empty",
+			want: TTDStats{},
+		},
+		{
+			name: "single measurement",
+			ttds: seconds(7),
+			want: TTDStats{Count: 1, Min: 7 * time.Second, Median: 7 * time.Second, P95: 7 * time.Second, Max: 7 * time.Second, Mean: 7 * time.Second},
+		},
+		{
+			// The median of an even number of measurements is the mean of the two middle ones
+			name: "even count unsorted",
+			ttds: seconds(4, 1, 3, 2),
+			want: TTDStats{Count: 4, Min: time.Second, Median: 2500 * time.Millisecond, P95: 4 * time.Second, Max: 4 * time.Second, Mean: 2500 * time.Millisecond},
+		},
+		{
+			// The p95 of 20 measurements is the 19th, ceil(0.95 × 20), without interpolation
+			name: "nearest rank p95",
+			ttds: seconds(oneToTwenty...),
+			want: TTDStats{Count: 20, Min: time.Second, Median: 10500 * time.Millisecond, P95: 19 * time.Second, Max: 20 * time.Second, Mean: 10500 * time.Millisecond},
+		},
+		{
+			name: "odd count",
+			ttds: seconds(oneToTwentyOne...),
+			want: TTDStats{Count: 21, Min: time.Second, Median: 11 * time.Second, P95: 20 * time.Second, Max: 21 * time.Second, Mean: 11 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.ttds)
+			if got := NewTTDStats(tt.ttds); got != tt.want {
+				t.Errorf("NewTTDStats() = %+v, want %+v", got, tt.want)
+			}
+			if !slices.Equal(tt.ttds, input) {
+				t.Errorf("NewTTDStats() modified its input")
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := seconds(10, 20, 30, 40, 50)
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 10 * time.Second},
+		{20, 10 * time.Second},
+		{21, 20 * time.Second},
+		{50, 30 * time.Second},
+		{95, 50 * time.Second},
+		{100, 50 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+// ttdReports returns two executions whose detected steps took 10s, 20s, 40s and 10m to detect
+func ttdReports() []models.GetExecutionResponse {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	yes := boolPtr(true)
+	alert := func(integration string, after time.Duration) models.Correlation {
+		return models.Correlation{Name: integration + " alert", IntegrationType: integration, DetectionTime: t0.Add(after)}
+	}
+
+	return []models.GetExecutionResponse{
+		{
+			ID:         "e1",
+			AttackName: "Discovery",
+			Assets: []models.AssetExecutionDetails{{
+				AssetID: "a1", Hostname: "host-1",
+				Steps: []models.GetExecutionResponseAssetStep{
+					{
+						ActionID: "act-1", Name: "Dump credentials", Success: yes, CreatedAt: &t0,
+						Correlations: []models.Correlation{
+							alert("edr", -time.Minute), // Before the step started, ignored
+							{Name: "no time", IntegrationType: "edr"},
+							alert("edr", 30*time.Second),
+							{Name: "siem alert", Source: "siem", DetectionTime: t0.Add(10 * time.Second)},
+						},
+					},
+					{
+						// Only alerted before it started, so unmeasured
+						ActionID: "act-2", Success: yes, CreatedAt: &t0,
+						Correlations: []models.Correlation{alert("edr", -5*time.Second)},
+					},
+					{ActionID: "act-3", CreatedAt: &t0},
+					{
+						ActionID: "act-4", Success: yes, CreatedAt: &t0,
+						Correlations: []models.Correlation{alert("edr", 20*time.Second)},
+						ActionSteps: []models.GetExecutionResponseAssetStep{{
+							// Measured from its last update without a creation time
+							ActionID: "act-5", Success: yes, UpdatedAt: &t0,
+							Correlations: []models.Correlation{alert("edr", 40*time.Second)},
+						}},
+					},
+				},
+			}},
+		},
+		{
+			ID:         "e2",
+			AttackName: "Ransomware",
+			Assets: []models.AssetExecutionDetails{{
+				AssetID: "a2",
+				Steps: []models.GetExecutionResponseAssetStep{{
+					ActionID: "act-1", Success: yes, CreatedAt: &t0,
+					Correlations: []models.Correlation{alert("edr", 10*time.Minute)},
+				}},
+			}},
+		},
+	}
+}
+
+// ttdKeys returns the keys of groups
+func ttdKeys(groups []GroupTTD) []string {
+	keys := make([]string, len(groups))
+	for i, g := range groups {
+		keys[i] = g.Key
+	}
+	return keys
+}
+
+func TestTimeToDetect(t *testing.T) {
+	r := TimeToDetect(ttdReports(), TTDOpts{})
+
+	if len(r.Steps) != 4 || r.Undetected != 0 || r.Unmeasured != 1 {
+		t.Fatalf("TimeToDetect() = %d measured, %d undetected and %d unmeasured steps, want 4, 0 and 1", len(r.Steps), r.Undetected, r.Unmeasured)
+	}
+
+	// The earliest alert after the step started is used
+	first := r.Steps[0]
+	if first.TTD != 10*time.Second || first.IntegrationType != "siem" || first.AlertName != "siem alert" || first.Hostname != "host-1" {
+		t.Errorf("first step = %+v, want the siem alert after 10s", first)
+	}
+	if nested := r.Steps[2]; nested.ActionID != "act-5" || nested.TTD != 40*time.Second {
+		t.Errorf("nested step = %+v, want act-5 after 40s", nested)
+	}
+
+	if r.Overall.Count != 4 || r.Overall.Median != 30*time.Second || r.Overall.P95 != 10*time.Minute {
+		t.Errorf("overall = %+v, want 4 measurements with a 30s median and a 10m p95", r.Overall)
+	}
+
+	if got := ttdKeys(r.Actions); !slices.Equal(got, []string{"act-1", "act-5", "act-4"}) {
+		t.Errorf("actions = %v, want the slowest p95 first", got)
+	}
+	if got := ttdKeys(r.Executions); !slices.Equal(got, []string{"e2", "e1"}) || r.Executions[1].Name != "Discovery" {
+		t.Errorf("executions = %+v", r.Executions)
+	}
+
+	// Each integration is measured with its own earliest alert of each step
+	if got := ttdKeys(r.Integrations); !slices.Equal(got, []string{"edr", "siem"}) {
+		t.Fatalf("integrations = %v", got)
+	}
+	if edr := r.Integrations[0]; edr.Count != 4 || edr.Min != 20*time.Second {
+		t.Errorf("edr = %+v, want 4 measurements from 20s", edr.TTDStats)
+	}
+
+	// Q1 is 10s and Q3 is 40s, so the Tukey fence is 40s + 1.5 × 30s
+	if r.OutlierThreshold != 85*time.Second {
+		t.Errorf("outlier threshold = %v, want 1m25s", r.OutlierThreshold)
+	}
+	if len(r.Outliers) != 1 || r.Outliers[0].ExecutionID != "e2" {
+		t.Errorf("outliers = %+v, want the step of e2", r.Outliers)
+	}
+}
+
+func TestTimeToDetectUndetectedAndUnmeasured(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	yes, no := boolPtr(true), boolPtr(false)
+	alert := models.Correlation{Name: "alert", IntegrationType: "edr", DetectionTime: t0.Add(time.Minute)}
+
+	tests := []struct {
+		name           string
+		step           models.GetExecutionResponseAssetStep
+		wantMeasured   int
+		wantUndetected int
+		wantUnmeasured int
+	}{
+		{
+			name:         "measured",
+			step:         models.GetExecutionResponseAssetStep{Success: yes, CreatedAt: &t0, Correlations: []models.Correlation{alert}},
+			wantMeasured: 1,
+		},
+		{
+			name:           "succeeded without correlations",
+			step:           models.GetExecutionResponseAssetStep{Success: yes, CreatedAt: &t0},
+			wantUndetected: 1,
+		},
+		{
+			name: "prevented without correlations",
+			step: models.GetExecutionResponseAssetStep{Success: no, CreatedAt: &t0},
+		},
+		{
+			name:           "correlation without a detection time",
+			step:           models.GetExecutionResponseAssetStep{Success: yes, CreatedAt: &t0, Correlations: []models.Correlation{{Name: "alert", IntegrationType: "edr"}}},
+			wantUnmeasured: 1,
+		},
+		{
+			name:           "step without creation or update time",
+			step:           models.GetExecutionResponseAssetStep{Success: yes, Correlations: []models.Correlation{alert}},
+			wantUnmeasured: 1,
+		},
+		{
+			name:           "prevented step with an unmeasurable alert",
+			step:           models.GetExecutionResponseAssetStep{Success: no, Correlations: []models.Correlation{alert}},
+			wantUnmeasured: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.step.ActionID = "act-1"
+			reports := []models.GetExecutionResponse{{
+				ID:     "e1",
+				Assets: []models.AssetExecutionDetails{{AssetID: "a1", Steps: []models.GetExecutionResponseAssetStep{tt.step}}},
+			}}
+
+			r := TimeToDetect(reports, TTDOpts{})
+			if len(r.Steps) != tt.wantMeasured || r.Undetected != tt.wantUndetected || r.Unmeasured != tt.wantUnmeasured {
+				t.Errorf("TimeToDetect() = %d measured, %d undetected and %d unmeasured steps, want %d, %d and %d",
+					len(r.Steps), r.Undetected, r.Unmeasured, tt.wantMeasured, tt.wantUndetected, tt.wantUnmeasured)
+			}
+		})
+	}
+}
+
+func TestTimeToDetectOutlierThreshold(t *testing.T) {
+	r := TimeToDetect(ttdReports(), TTDOpts{OutlierThreshold: 30 * time.Second})
+
+	var got []time.Duration
+	for _, m := range r.Outliers {
+		got = append(got, m.TTD)
+	}
+	if !slices.Equal(got, []time.Duration{10 * time.Minute, 40 * time.Second}) {
+		t.Errorf("outliers = %v, want the steps above 30s, slowest first", got)
+	}
+}
+
+func TestTimeToDetectTooFewForFence(t *testing.T) {
+	reports := ttdReports()[1:]
+	if r := TimeToDetect(reports, TTDOpts{}); r.OutlierThreshold != 0 || len(r.Outliers) != 0 {
+		t.Errorf("TimeToDetect() of a single measurement = threshold %v and %d outliers, want none", r.OutlierThreshold, len(r.Outliers))
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultResource is the bucket used when the server does not send x-ratelimit-resource
+const defaultResource = "default"
+
+// paceThreshold is the fraction of the server limit below which requests start being paced
+const paceThreshold = 0.5
+
+// ResetAt returns the time at which the rate limit window resets, or the zero time if unknown.
+// x-ratelimit-reset is accepted both as a unix timestamp and as seconds from now.
+func (r RateInfo) ResetAt(now time.Time) time.Time {
+	switch {
+	case r.Reset <= 0:
+		return time.Time{}
+	case r.Reset > 1_000_000_000:
+		return time.Unix(r.Reset, 0)
+	default:
+		return now.Add(time.Duration(r.Reset) * time.Second)
+	}
+}
+
+// resourceBucket holds the server reported state of a single rate limited resource
+type resourceBucket struct {
+	info         RateInfo
+	limiter      *rate.Limiter
+	blockedUntil time.Time
+}
+
+// rateTracker keeps the rate limit state reported by the server on every response,
+// with one bucket per x-ratelimit-resource, and paces requests before the server rejects them
+type rateTracker struct {
+	mu      sync.Mutex
+	pacing  bool
+	latest  RateInfo
+	buckets map[string]*resourceBucket
+	routes  map[string]string // route key -> resource, learned from responses
+}
+
+func newRateTracker(pacing bool) *rateTracker {
+	return &rateTracker{
+		pacing:  pacing,
+		buckets: make(map[string]*resourceBucket),
+		routes:  make(map[string]string),
+	}
+}
+
+// routeKey reduces a request uri to the API collection it targets, e.g. /api/v2/executions
+func routeKey(uri string) string {
+	parts := strings.SplitN(strings.Trim(uri, "/"), "/", 4)
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// observe records the rate limit headers of a response to uri
+func (t *rateTracker) observe(uri string, statusCode int, info RateInfo) {
+	if info == (RateInfo{}) {
+		return
+	}
+
+	resource := info.Resource
+	if resource == "" {
+		resource = defaultResource
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.latest = info
+	t.routes[routeKey(uri)] = resource
+
+	bucket, ok := t.buckets[resource]
+	if !ok {
+		bucket = &resourceBucket{limiter: rate.NewLimiter(rate.Inf, 1)}
+		t.buckets[resource] = bucket
+	}
+	bucket.info = info
+
+	now := time.Now()
+	resetAt := info.ResetAt(now)
+
+	switch {
+	case statusCode == http.StatusTooManyRequests && info.RetryAfter > 0:
+		bucket.blockedUntil = now.Add(time.Duration(info.RetryAfter) * time.Second)
+	case info.Limit > 0 && info.Remaining <= 0 && resetAt.After(now):
+		bucket.blockedUntil = resetAt
+	default:
+		bucket.blockedUntil = time.Time{}
+	}
+
+	if !t.pacing {
+		return
+	}
+
+	// Spread the remaining budget over the rest of the window once it runs low
+	window := resetAt.Sub(now)
+	if info.Limit > 0 && info.Remaining > 0 && window > 0 &&
+		float64(info.Remaining) < float64(info.Limit)*paceThreshold {
+		bucket.limiter.SetLimitAt(now, rate.Limit(float64(info.Remaining)/window.Seconds()))
+		bucket.limiter.SetBurstAt(now, 1)
+	} else {
+		bucket.limiter.SetLimitAt(now, rate.Inf)
+	}
+}
+
+// acquire waits, according to the limiter mode, until the bucket of uri allows another request
+func (t *rateTracker) acquire(ctx context.Context, uri string, mode LimiterMode, maxWait time.Duration) error {
+	t.mu.Lock()
+	resource, ok := t.routes[routeKey(uri)]
+	var bucket *resourceBucket
+	if ok {
+		bucket = t.buckets[resource]
+	}
+	var blockedUntil time.Time
+	if bucket != nil {
+		blockedUntil = bucket.blockedUntil
+	}
+	pacing := t.pacing
+	t.mu.Unlock()
+
+	if bucket == nil {
+		return nil
+	}
+
+	if wait := time.Until(blockedUntil); wait > 0 {
+		if mode == LimiterFailFast || (mode == LimiterBoundedWait && wait > maxWait) {
+			return fmt.Errorf("%w: %s: retry after %.1f seconds", ErrRateLimited, resource, wait.Seconds())
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	if !pacing {
+		return nil
+	}
+
+	limiter := &RateLimiter{limiter: bucket.limiter}
+	return limiter.Acquire(ctx, mode, maxWait)
+}
+
+// latestInfo returns the most recently observed rate limit headers
+func (t *rateTracker) latestInfo() RateInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.latest
+}
+
+// resourceInfo returns the most recently observed rate limit headers for a resource
+func (t *rateTracker) resourceInfo(resource string) (RateInfo, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, ok := t.buckets[resource]
+	if !ok {
+		return RateInfo{}, false
+	}
+	return bucket.info, true
+}
+
+// RateInfo returns the rate limit headers of the most recent response
+func (g *HTTPAPI) RateInfo() RateInfo {
+	return g.rates.latestInfo()
+}
+
+// ResourceRateInfo returns the rate limit headers last reported for the given x-ratelimit-resource
+func (g *HTTPAPI) ResourceRateInfo(resource string) (RateInfo, bool) {
+	return g.rates.resourceInfo(resource)
+}
@@ -1,385 +1,659 @@
-package api
-
-import (
-	"bytes"
-	"context"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"strconv"
-	"time"
-
-	"encoding/json"
-
-	"golang.org/x/time/rate"
-)
-
-// RateLimiter wraps the golang.org/x/time/rate Limiter
-type RateLimiter struct {
-	limiter *rate.Limiter // Token bucket rate limiter
-	limit   int           // Store original limit value
-}
-
-// NewRateLimiter creates a new rate limiter with default values
-func NewRateLimiter(requestsPerMinute int) *RateLimiter {
-	// Convert requests per minute to requests per second
-	rps := float64(requestsPerMinute) / 60.0
-	// Create a token bucket with capacity equal to the limit and refill rate of rps
-	limiter := rate.NewLimiter(rate.Limit(rps), requestsPerMinute)
-
-	return &RateLimiter{
-		limiter: limiter,
-		limit:   requestsPerMinute,
-	}
-}
-
-// IsAllowed checks if a request is allowed based on rate limits without blocking
-// Returns true if allowed, false if rate limited, and duration to wait if limited
-func (r *RateLimiter) IsAllowed() (bool, time.Duration) {
-	// Use Reserve to get information about when a token would be available
-	reservation := r.limiter.Reserve()
-	if !reservation.OK() {
-		// This generally shouldn't happen with the standard token bucket,
-		// but we'll handle it anyway
-		reservation.Cancel()
-		return false, 0
-	}
-
-	// Check if we need to wait
-	delay := reservation.Delay()
-	if delay == 0 {
-		// No need to wait, token available immediately
-		return true, 0
-	}
-
-	// We'd need to wait - cancel the reservation since we're not actually consuming yet
-	// and return the wait time
-	reservation.Cancel()
-	return false, delay
-}
-
-// Allow checks if a request is allowed based on rate limits and consumes a token if available
-func (r *RateLimiter) Allow() bool {
-	return r.limiter.Allow()
-}
-
-// Wait blocks until a request can be allowed or the context is done
-func (r *RateLimiter) Wait(ctx context.Context) error {
-	return r.limiter.Wait(ctx)
-}
-
-// WaitMaxDuration tries to wait for a token but only up to the specified max duration
-func (r *RateLimiter) WaitMaxDuration(ctx context.Context, maxWait time.Duration) error {
-	// Create a new context with timeout
-	ctx, cancel := context.WithTimeout(ctx, maxWait)
-	defer cancel()
-
-	return r.limiter.Wait(ctx)
-}
-
-// RemainingTokens returns an estimate of the number of remaining requests
-func (r *RateLimiter) RemainingTokens() float64 {
-	return r.limiter.Tokens()
-}
-
-// HTTPAPI represents an HTTP API client
-type HTTPAPI struct {
-	BaseURL     string
-	baseURL     *url.URL
-	client      *http.Client
-	APIKey      string
-	rateLimiter *RateLimiter
-}
-
-// NewHTTPAPI creates a new API client with default rate limit of 100 reqs/min
-func NewHTTPAPI(baseURL, apiKey string, client ...*http.Client) (*HTTPAPI, error) {
-	parsedURL, err := url.Parse(baseURL)
-	if err != nil {
-		return nil, err
-	}
-
-	transport := &http.Transport{
-		MaxIdleConns:        100,
-		MaxConnsPerHost:     10,
-		MaxIdleConnsPerHost: 10,
-		IdleConnTimeout:     90 * time.Second,
-	}
-
-	httpClient := &http.Client{Timeout: 60 * time.Second, Transport: transport}
-	if len(client) > 0 {
-		httpClient = client[0]
-	}
-
-	return &HTTPAPI{
-		BaseURL:     baseURL,
-		baseURL:     parsedURL,
-		client:      httpClient,
-		APIKey:      apiKey,
-		rateLimiter: NewRateLimiter(100), // Default rate limit: 100 requests per minute
-	}, nil
-}
-
-// SetRateLimit updates the rate limiter with a new limit
-func (g *HTTPAPI) SetRateLimit(requestsPerMinute int) {
-	g.rateLimiter = NewRateLimiter(requestsPerMinute)
-}
-
-var (
-	ErrApiKeyInvalid = errors.New("invalid api key")
-	ErrNotFound      = errors.New("resource not found")
-	ErrRateLimited   = errors.New("rate limit exceeded")
-)
-
-type ErrorItem struct {
-	Name   string         `json:"name" description:"For example, name of the parameter that caused the error"`
-	Reason string         `json:"reason" description:"Human readable error message"`
-	More   map[string]any `json:"more,omitempty" description:"Additional information about the error"`
-}
-
-type APIError struct {
-	Code   int         `json:"code"`
-	Detail string      `json:"detail"`
-	Title  string      `json:"title"`
-	Status int         `json:"status,omitempty"`
-	Errors []ErrorItem `json:"errors,omitempty" xml:"errors,omitempty"`
-}
-
-func (a *APIError) GetError() ErrorItem {
-	return ErrorItem{
-		Name:   a.Title,
-		Reason: fmt.Sprintf("%v", a.Status),
-	}
-}
-
-func (g *HTTPAPI) ResolveBase(base *url.URL, uri string) string {
-	rel := &url.URL{Path: uri}
-	u := base.ResolveReference(rel)
-
-	return u.String()
-}
-
-type ReqOptions struct {
-	Params  map[string]string
-	Headers map[string]string
-}
-
-func (g *HTTPAPI) Req(ctx context.Context, method string, uri string, postBody []byte, isJSON bool, options ...ReqOptions) ([]byte, int, string, error) {
-	return g.reqBase(ctx, g.baseURL, method, uri, postBody, isJSON, options...)
-}
-
-// RateInfo contains information about API rate limits
-type RateInfo struct {
-	Limit      int    // x-ratelimit-limit
-	Remaining  int    // x-ratelimit-remaining
-	Used       int    // x-ratelimit-used
-	Reset      int64  // x-ratelimit-reset
-	RetryAfter int64  // x-ratelimit-retry-after
-	Resource   string // x-ratelimit-resource
-}
-
-func parseRateLimitHeaders(headers http.Header) RateInfo {
-	info := RateInfo{}
-
-	if v := headers.Get("x-ratelimit-limit"); v != "" {
-		info.Limit, _ = strconv.Atoi(v)
-	}
-	if v := headers.Get("x-ratelimit-remaining"); v != "" {
-		info.Remaining, _ = strconv.Atoi(v)
-	}
-	if v := headers.Get("x-ratelimit-used"); v != "" {
-		info.Used, _ = strconv.Atoi(v)
-	}
-	if v := headers.Get("x-ratelimit-reset"); v != "" {
-		info.Reset, _ = strconv.ParseInt(v, 10, 64)
-	}
-	if v := headers.Get("x-ratelimit-retry-after"); v != "" {
-		info.RetryAfter, _ = strconv.ParseInt(v, 10, 64)
-	}
-	if v := headers.Get("x-ratelimit-resource"); v != "" {
-		info.Resource = v
-	}
-
-	return info
-}
-
-func (g *HTTPAPI) reqBase(ctx context.Context, base *url.URL, method string, uri string, postBody []byte, isJSON bool, options ...ReqOptions) ([]byte, int, string, error) {
-	// We can use either IsAllowed or Wait depending on whether we want to block or return immediately
-	// Let's implement both approaches with priority to IsAllowed for quick checks
-
-	// First check if we can make the request without waiting
-	allowed, waitTime := g.rateLimiter.IsAllowed()
-	if !allowed {
-		// If wait time is reasonable (less than 5 seconds), we can wait
-		if waitTime <= 5*time.Second {
-			select {
-			case <-time.After(waitTime):
-				// Continue after waiting the short duration
-			case <-context.Background().Done():
-				return nil, 0, "", context.Background().Err()
-			}
-		} else {
-			// Wait time is too long, let's use the Wait method with a max timeout
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			// Try to wait for a token, but only up to our limit
-			if err := g.rateLimiter.WaitMaxDuration(ctx, 5*time.Second); err != nil {
-				// If we couldn't get a token in time, return a rate limit error
-				if errors.Is(err, context.DeadlineExceeded) {
-					return nil, 0, "", fmt.Errorf("%w: retry after %.1f seconds",
-						ErrRateLimited, waitTime.Seconds())
-				}
-				return nil, 0, "", err
-			}
-		}
-	}
-
-	buf := bytes.NewBuffer(postBody)
-	req, err := http.NewRequestWithContext(ctx, method, g.ResolveBase(base, uri), buf)
-	if err != nil {
-		return nil, 0, "", err
-	}
-
-	if isJSON {
-		req.Header.Set("Accept", "application/json")
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", g.APIKey))
-
-	if len(options) > 0 {
-		optionsVal := options[0]
-		for k, v := range optionsVal.Headers {
-			req.Header[k] = []string{v}
-		}
-		if params := optionsVal.Params; len(params) > 0 {
-			q := req.URL.Query()
-			for k, v := range params {
-				q.Add(k, v)
-			}
-			req.URL.RawQuery = q.Encode()
-		}
-	}
-
-	response, err := g.client.Do(req)
-	if err != nil {
-		return nil, 0, "", err
-	}
-	defer response.Body.Close()
-
-	// Parse rate limit headers from response
-	rateInfo := parseRateLimitHeaders(response.Header)
-
-	// If we received a rate limit response, update our local limiter if needed
-	if response.StatusCode == http.StatusTooManyRequests {
-		// Update rate limiter if we get new limit information
-		if rateInfo.Limit > 0 && rateInfo.Limit != g.rateLimiter.limit {
-			g.SetRateLimit(rateInfo.Limit)
-		}
-
-		// If there's a retry-after header, return appropriate error
-		if rateInfo.RetryAfter > 0 {
-			return nil, response.StatusCode, response.Header.Get("Content-Type"),
-				fmt.Errorf("%w: retry after %d seconds", ErrRateLimited, rateInfo.RetryAfter)
-		}
-
-		return nil, response.StatusCode, response.Header.Get("Content-Type"), ErrRateLimited
-	}
-
-	body, err := io.ReadAll(response.Body)
-	return body, response.StatusCode, response.Header.Get("Content-Type"), err
-}
-
-func (g *HTTPAPI) ReqBuf(ctx context.Context, method string, uri string, buf []byte, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	body, statusCode, _, err := g.Req(ctx, method, uri, buf, true, options...)
-	if err != nil {
-		return nil, err
-	}
-
-	switch statusCode {
-	case http.StatusOK, http.StatusCreated:
-		if err := json.Unmarshal(body, dest); err != nil {
-			return nil, err
-		}
-
-		return dest, nil
-	case http.StatusBadRequest:
-		var message APIError
-		if err := json.Unmarshal(body, &message); err != nil {
-			return nil, err
-		}
-
-		apiErr := message.GetError()
-
-		return nil, fmt.Errorf("%v: %v", apiErr.Name, apiErr.Reason)
-	case http.StatusNotFound:
-		var message APIError
-		if err := json.Unmarshal(body, &message); err != nil {
-			return nil, ErrNotFound
-		}
-		apiErr := message.GetError()
-
-		return nil, fmt.Errorf("%v: %v", apiErr.Name, apiErr.Reason)
-	case http.StatusUnauthorized:
-		var message APIError
-		if err := json.Unmarshal(body, &message); err != nil {
-			return nil, ErrApiKeyInvalid
-		}
-		apiErr := message.GetError()
-
-		return nil, fmt.Errorf("%v: %v", apiErr.Name, apiErr.Reason)
-	case http.StatusTooManyRequests:
-		var message APIError
-		if err := json.Unmarshal(body, &message); err != nil {
-			return nil, ErrRateLimited
-		}
-		apiErr := message.GetError()
-
-		return nil, fmt.Errorf("%v: %v", apiErr.Name, apiErr.Reason)
-	default:
-		var message APIError
-		if err := json.Unmarshal(body, &message); err != nil {
-			return nil, fmt.Errorf("%s: %v", ErrNotFound.Error(), errors.New("invalid response content"))
-		}
-
-		apiErr := message.GetError()
-
-		return nil, fmt.Errorf("%v: %v", apiErr.Name, apiErr.Reason)
-	}
-}
-
-func (g *HTTPAPI) ReqJSON(ctx context.Context, method string, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	var err error
-	var buf []byte
-
-	if post != nil {
-		buf, err = json.Marshal(post)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	msg, err := g.ReqBuf(ctx, method, uri, buf, dest, options...)
-	if err != nil {
-		return msg, err
-	}
-
-	return dest, nil
-}
-
-func (g *HTTPAPI) GetJSON(ctx context.Context, uri string, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	return g.ReqJSON(ctx, "GET", uri, nil, dest, options...)
-}
-
-func (g *HTTPAPI) PostJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	return g.ReqJSON(ctx, "POST", uri, post, dest, options...)
-}
-
-func (g *HTTPAPI) PutJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	return g.ReqJSON(ctx, "PUT", uri, post, dest, options...)
-}
-
-func (g *HTTPAPI) DeleteJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
-	return g.ReqJSON(ctx, "DELETE", uri, post, dest, options...)
-}
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encoding/json"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter wraps the golang.org/x/time/rate Limiter
+type RateLimiter struct {
+	limiter *rate.Limiter // Token bucket rate limiter, safe for concurrent use
+	mu      sync.Mutex    // Guards limit
+	limit   int           // Store original limit value
+}
+
+// NewRateLimiter creates a new rate limiter with default values
+func NewRateLimiter(requestsPerMinute int) *RateLimiter {
+	// Convert requests per minute to requests per second
+	rps := float64(requestsPerMinute) / 60.0
+	// Create a token bucket with capacity equal to the limit and refill rate of rps
+	limiter := rate.NewLimiter(rate.Limit(rps), requestsPerMinute)
+
+	return &RateLimiter{
+		limiter: limiter,
+		limit:   requestsPerMinute,
+	}
+}
+
+// IsAllowed checks if a request is allowed based on rate limits without blocking
+// Returns true if allowed, false if rate limited, and duration to wait if limited
+func (r *RateLimiter) IsAllowed() (bool, time.Duration) {
+	// Use Reserve to get information about when a token would be available
+	reservation := r.limiter.Reserve()
+	if !reservation.OK() {
+		// This generally shouldn't happen with the standard token bucket,
+		// but we'll handle it anyway
+		reservation.Cancel()
+		return false, 0
+	}
+
+	// Check if we need to wait
+	delay := reservation.Delay()
+	if delay == 0 {
+		// No need to wait, token available immediately
+		return true, 0
+	}
+
+	// We'd need to wait - cancel the reservation since we're not actually consuming yet
+	// and return the wait time
+	reservation.Cancel()
+	return false, delay
+}
+
+// Allow checks if a request is allowed based on rate limits and consumes a token if available
+func (r *RateLimiter) Allow() bool {
+	return r.limiter.Allow()
+}
+
+// Wait blocks until a request can be allowed or the context is done
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	return r.limiter.Wait(ctx)
+}
+
+// WaitMaxDuration tries to wait for a token but only up to the specified max duration
+func (r *RateLimiter) WaitMaxDuration(ctx context.Context, maxWait time.Duration) error {
+	// Create a new context with timeout
+	ctx, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+
+	return r.limiter.Wait(ctx)
+}
+
+// SetLimit changes the limit of the existing token bucket without discarding its state
+func (r *RateLimiter) SetLimit(requestsPerMinute int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limiter.SetLimit(rate.Limit(float64(requestsPerMinute) / 60.0))
+	r.limiter.SetBurst(requestsPerMinute)
+	r.limit = requestsPerMinute
+}
+
+// Limit returns the current limit in requests per minute
+func (r *RateLimiter) Limit() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.limit
+}
+
+// RemainingTokens returns an estimate of the number of remaining requests
+func (r *RateLimiter) RemainingTokens() float64 {
+	return r.limiter.Tokens()
+}
+
+// LimiterMode controls what a request does when the client side rate limit is exhausted
+type LimiterMode int
+
+const (
+	// LimiterBoundedWait waits up to the configured max wait for a token, then fails with ErrRateLimited
+	LimiterBoundedWait LimiterMode = iota
+	// LimiterBlock waits until a token is available or the context is done. It fails with ErrRateLimited
+	// without waiting if the token would only be available after the context deadline.
+	LimiterBlock
+	// LimiterFailFast fails with ErrRateLimited immediately if no token is available
+	LimiterFailFast
+)
+
+// DefaultMaxRateLimitWait is the longest a request waits for a token in LimiterBoundedWait mode
+const DefaultMaxRateLimitWait = 5 * time.Second
+
+// String returns the name of the mode
+func (m LimiterMode) String() string {
+	switch m {
+	case LimiterBoundedWait:
+		return "bounded"
+	case LimiterBlock:
+		return "block"
+	case LimiterFailFast:
+		return "failfast"
+	default:
+		return fmt.Sprintf("LimiterMode(%d)", int(m))
+	}
+}
+
+// Acquire takes a token according to the mode, always honoring the cancellation of ctx
+func (r *RateLimiter) Acquire(ctx context.Context, mode LimiterMode, maxWait time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	switch mode {
+	case LimiterBlock:
+		if err := r.limiter.Wait(ctx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			// The token would only be available after the context deadline
+			return fmt.Errorf("%w: %v", ErrRateLimited, err)
+		}
+		return nil
+	case LimiterFailFast:
+		if allowed, waitTime := r.IsAllowed(); !allowed {
+			return fmt.Errorf("%w: retry after %.1f seconds", ErrRateLimited, waitTime.Seconds())
+		}
+		return nil
+	default:
+		reservation := r.limiter.Reserve()
+		if !reservation.OK() {
+			return ErrRateLimited
+		}
+
+		waitTime := reservation.Delay()
+		if waitTime == 0 {
+			return nil
+		}
+
+		if waitTime > maxWait {
+			// Give the token back, we are not going to use it
+			reservation.Cancel()
+			return fmt.Errorf("%w: retry after %.1f seconds", ErrRateLimited, waitTime.Seconds())
+		}
+
+		if err := sleepContext(ctx, waitTime); err != nil {
+			reservation.Cancel()
+			return err
+		}
+		return nil
+	}
+}
+
+// HTTPAPI represents an HTTP API client.
+// It is safe for concurrent use by multiple goroutines; use SetAPIKey and SetBaseURL
+// rather than assigning APIKey and BaseURL while requests may be in flight.
+type HTTPAPI struct {
+	mu          sync.RWMutex // Guards the mutable settings below
+	BaseURL     string
+	baseURL     *url.URL
+	client      *http.Client
+	APIKey      string
+	userAgent   string
+	rateLimiter *RateLimiter
+	limiterMode LimiterMode
+	maxWait     time.Duration
+	rates       *rateTracker
+	retryPolicy RetryPolicy
+	middleware  []Middleware
+}
+
+// NewHTTPAPI creates a new API client with default rate limit of 100 reqs/min and the default retry policy.
+// If a client is passed it is used for requests, receiving the default tuned transport when it has none.
+// Use New for finer grained configuration.
+func NewHTTPAPI(baseURL, apiKey string, client ...*http.Client) (*HTTPAPI, error) {
+	var opts []Option
+	if len(client) > 0 && client[0] != nil {
+		opts = append(opts, WithHTTPClient(client[0]))
+	}
+
+	return New(baseURL, apiKey, opts...)
+}
+
+// SetLimiterMode changes how requests wait for the client side rate limiter.
+// maxWait is only used by LimiterBoundedWait.
+func (g *HTTPAPI) SetLimiterMode(mode LimiterMode, maxWait time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.limiterMode = mode
+	g.maxWait = maxWait
+}
+
+// SetRateLimit updates the rate limiter with a new limit
+func (g *HTTPAPI) SetRateLimit(requestsPerMinute int) {
+	g.rateLimiter.SetLimit(requestsPerMinute)
+}
+
+// SetAPIKey replaces the API key used for subsequent requests
+func (g *HTTPAPI) SetAPIKey(apiKey string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.APIKey = apiKey
+}
+
+// SetBaseURL replaces the base URL used for subsequent requests
+func (g *HTTPAPI) SetBaseURL(baseURL string) error {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.BaseURL = baseURL
+	g.baseURL = parsedURL
+	return nil
+}
+
+// requestSettings is a consistent copy of the mutable client settings used by a single request
+type requestSettings struct {
+	apiKey      string
+	limiterMode LimiterMode
+	maxWait     time.Duration
+	retryPolicy RetryPolicy
+}
+
+// settings returns a snapshot of the mutable client settings
+func (g *HTTPAPI) settings() requestSettings {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return requestSettings{
+		apiKey:      g.APIKey,
+		limiterMode: g.limiterMode,
+		maxWait:     g.maxWait,
+		retryPolicy: g.retryPolicy,
+	}
+}
+
+// base returns the parsed base URL
+func (g *HTTPAPI) base() *url.URL {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.baseURL
+}
+
+var (
+	ErrApiKeyInvalid = errors.New("invalid api key")
+	ErrNotFound      = errors.New("resource not found")
+	ErrRateLimited   = errors.New("rate limit exceeded")
+	ErrForbidden     = errors.New("permission denied")
+	ErrConflict      = errors.New("resource conflict")
+	ErrServerError   = errors.New("server error")
+)
+
+type ErrorItem struct {
+	Name   string         `json:"name" description:"For example, name of the parameter that caused the error"`
+	Reason string         `json:"reason" description:"Human readable error message"`
+	More   map[string]any `json:"more,omitempty" description:"Additional information about the error"`
+}
+
+// APIError is the error returned for non-2xx responses from the API.
+// It can be inspected with errors.As and matched with errors.Is against the Err* sentinels.
+type APIError struct {
+	Code   int         `json:"code"`
+	Detail string      `json:"detail"`
+	Title  string      `json:"title"`
+	Status int         `json:"status,omitempty"`
+	Errors []ErrorItem `json:"errors,omitempty" xml:"errors,omitempty"`
+
+	StatusCode int         `json:"-"` // HTTP status code of the response
+	RequestID  string      `json:"-"` // Value of the X-Request-Id response header, if any
+	RetryAfter int64       `json:"-"` // Seconds to wait before retrying, if the server provided it
+	Header     http.Header `json:"-"` // Response headers
+}
+
+func (a *APIError) GetError() ErrorItem {
+	return ErrorItem{
+		Name:   a.Title,
+		Reason: fmt.Sprintf("%v", a.Status),
+	}
+}
+
+// Error implements the error interface
+func (a *APIError) Error() string {
+	var sb strings.Builder
+
+	title := a.Title
+	if title == "" {
+		title = http.StatusText(a.StatusCode)
+	}
+	if title == "" {
+		title = "api error"
+	}
+	sb.WriteString(title)
+
+	if a.Detail != "" {
+		sb.WriteString(": ")
+		sb.WriteString(a.Detail)
+	}
+
+	if len(a.Errors) > 0 {
+		fields := make([]string, 0, len(a.Errors))
+		for _, item := range a.Errors {
+			fields = append(fields, fmt.Sprintf("%s: %s", item.Name, item.Reason))
+		}
+		sb.WriteString(" [")
+		sb.WriteString(strings.Join(fields, "; "))
+		sb.WriteString("]")
+	}
+
+	if a.RetryAfter > 0 {
+		fmt.Fprintf(&sb, ": retry after %d seconds", a.RetryAfter)
+	}
+
+	fmt.Fprintf(&sb, " (status %d", a.StatusCode)
+	if a.RequestID != "" {
+		fmt.Fprintf(&sb, ", request id %s", a.RequestID)
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// Is reports whether the error matches one of the package sentinels based on the HTTP status code
+func (a *APIError) Is(target error) bool {
+	statusCode := a.StatusCode
+	if statusCode == 0 {
+		statusCode = a.Status
+	}
+
+	switch target {
+	case ErrApiKeyInvalid:
+		return statusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return statusCode == http.StatusForbidden
+	case ErrNotFound:
+		return statusCode == http.StatusNotFound
+	case ErrConflict:
+		return statusCode == http.StatusConflict
+	case ErrRateLimited:
+		return statusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return statusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// newAPIError builds an APIError from a non-2xx response
+func newAPIError(statusCode int, headers http.Header, body []byte) *APIError {
+	apiErr := &APIError{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, apiErr); err != nil {
+			// Not an API error payload, keep a short excerpt of the body for context
+			apiErr = &APIError{Detail: truncate(strings.TrimSpace(string(body)), 256)}
+		}
+	}
+
+	apiErr.StatusCode = statusCode
+	apiErr.Header = headers
+	if headers != nil {
+		apiErr.RequestID = headers.Get("X-Request-Id")
+		apiErr.RetryAfter = parseRateLimitHeaders(headers).RetryAfter
+	}
+
+	return apiErr
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+func (g *HTTPAPI) ResolveBase(base *url.URL, uri string) string {
+	rel := &url.URL{Path: uri}
+	u := base.ResolveReference(rel)
+
+	return u.String()
+}
+
+type ReqOptions struct {
+	Params  map[string]string
+	Headers map[string]string
+}
+
+func (g *HTTPAPI) Req(ctx context.Context, method string, uri string, postBody []byte, isJSON bool, options ...ReqOptions) ([]byte, int, string, error) {
+	body, statusCode, contentType, _, err := g.reqBase(ctx, g.base(), method, uri, postBody, isJSON, options...)
+	return body, statusCode, contentType, err
+}
+
+// RateInfo contains information about API rate limits
+type RateInfo struct {
+	Limit      int    // x-ratelimit-limit
+	Remaining  int    // x-ratelimit-remaining
+	Used       int    // x-ratelimit-used
+	Reset      int64  // x-ratelimit-reset
+	RetryAfter int64  // x-ratelimit-retry-after
+	Resource   string // x-ratelimit-resource
+}
+
+func parseRateLimitHeaders(headers http.Header) RateInfo {
+	info := RateInfo{}
+
+	if v := headers.Get("x-ratelimit-limit"); v != "" {
+		info.Limit, _ = strconv.Atoi(v)
+	}
+	if v := headers.Get("x-ratelimit-remaining"); v != "" {
+		info.Remaining, _ = strconv.Atoi(v)
+	}
+	if v := headers.Get("x-ratelimit-used"); v != "" {
+		info.Used, _ = strconv.Atoi(v)
+	}
+	if v := headers.Get("x-ratelimit-reset"); v != "" {
+		info.Reset, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v := headers.Get("x-ratelimit-retry-after"); v != "" {
+		info.RetryAfter, _ = strconv.ParseInt(v, 10, 64)
+	} else if v := headers.Get("Retry-After"); v != "" {
+		info.RetryAfter = parseRetryAfter(v)
+	}
+	if v := headers.Get("x-ratelimit-resource"); v != "" {
+		info.Resource = v
+	}
+
+	return info
+}
+
+// parseRetryAfter parses a standard Retry-After header which is either delay seconds or an HTTP date
+func parseRetryAfter(v string) int64 {
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return secs
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if secs := int64(math.Ceil(time.Until(t).Seconds())); secs > 0 {
+			return secs
+		}
+	}
+
+	return 0
+}
+
+// reqBase runs the request through the middleware chain and the retrying transport
+func (g *HTTPAPI) reqBase(ctx context.Context, base *url.URL, method string, uri string, postBody []byte, isJSON bool, options ...ReqOptions) ([]byte, int, string, http.Header, error) {
+	req := &Request{
+		Operation: OperationFromContext(ctx),
+		Method:    method,
+		URI:       uri,
+		Base:      base,
+		Params:    map[string]string{},
+		Headers:   http.Header{},
+		Body:      postBody,
+		IsJSON:    isJSON,
+	}
+
+	if len(options) > 0 {
+		optionsVal := options[0]
+		for k, v := range optionsVal.Params {
+			req.Params[k] = v
+		}
+		for k, v := range optionsVal.Headers {
+			req.Headers[k] = []string{v}
+		}
+	}
+
+	resp, err := g.handler()(ctx, req)
+	if resp == nil {
+		return nil, 0, "", nil, err
+	}
+
+	return resp.Body, resp.StatusCode, resp.ContentType, resp.Header, err
+}
+
+// send performs the request, retrying transient failures according to the client's retry policy
+func (g *HTTPAPI) send(ctx context.Context, req *Request) (*Response, error) {
+	settings := g.settings()
+	policy := settings.retryPolicy
+
+	for attempt := 1; ; attempt++ {
+		resp, err := g.doRequest(ctx, settings, req)
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		if !policy.shouldRetry(ctx, req.Method, attempt, statusCode, err) {
+			return resp, err
+		}
+
+		var rateInfo RateInfo
+		if resp != nil {
+			rateInfo = resp.RateInfo
+		}
+		delay, ok := policy.backoff(attempt, rateInfo)
+		if !ok {
+			return resp, err
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+}
+
+// doRequest performs a single attempt of the request
+func (g *HTTPAPI) doRequest(ctx context.Context, settings requestSettings, r *Request) (*Response, error) {
+	if err := g.rateLimiter.Acquire(ctx, settings.limiterMode, settings.maxWait); err != nil {
+		return nil, err
+	}
+
+	// Wait for the server reported budget of the targeted resource
+	if err := g.rates.acquire(ctx, r.URI, settings.limiterMode, settings.maxWait); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(r.Body)
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.IsJSON {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", settings.apiKey))
+	req.Header.Set("User-Agent", g.userAgent)
+
+	for k, v := range r.Headers {
+		req.Header[k] = v
+	}
+
+	response, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// Parse rate limit headers from response and track them for pacing
+	rateInfo := parseRateLimitHeaders(response.Header)
+	g.rates.observe(r.URI, response.StatusCode, rateInfo)
+
+	resp := &Response{
+		StatusCode:  response.StatusCode,
+		ContentType: response.Header.Get("Content-Type"),
+		Header:      response.Header,
+		RateInfo:    rateInfo,
+	}
+
+	resp.Body, err = io.ReadAll(response.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	// If we received a rate limit response, update our local limiter if needed
+	if response.StatusCode == http.StatusTooManyRequests {
+		// Update rate limiter if we get new limit information
+		if rateInfo.Limit > 0 && rateInfo.Limit != g.rateLimiter.Limit() {
+			g.rateLimiter.SetLimit(rateInfo.Limit)
+		}
+
+		return resp, newAPIError(response.StatusCode, response.Header, resp.Body)
+	}
+
+	return resp, nil
+}
+
+func (g *HTTPAPI) ReqBuf(ctx context.Context, method string, uri string, buf []byte, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	body, statusCode, _, headers, err := g.reqBase(ctx, g.base(), method, uri, buf, true, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return nil, newAPIError(statusCode, headers, body)
+	}
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, dest); err != nil {
+			return nil, err
+		}
+	}
+
+	return dest, nil
+}
+
+func (g *HTTPAPI) ReqJSON(ctx context.Context, method string, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	var err error
+	var buf []byte
+
+	if post != nil {
+		buf, err = json.Marshal(post)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := g.ReqBuf(ctx, method, uri, buf, dest, options...)
+	if err != nil {
+		return msg, err
+	}
+
+	return dest, nil
+}
+
+func (g *HTTPAPI) GetJSON(ctx context.Context, uri string, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	return g.ReqJSON(ctx, "GET", uri, nil, dest, options...)
+}
+
+func (g *HTTPAPI) PostJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	return g.ReqJSON(ctx, "POST", uri, post, dest, options...)
+}
+
+func (g *HTTPAPI) PutJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	return g.ReqJSON(ctx, "PUT", uri, post, dest, options...)
+}
+
+func (g *HTTPAPI) DeleteJSON(ctx context.Context, uri string, post interface{}, dest interface{}, options ...ReqOptions) (interface{}, error) {
+	return g.ReqJSON(ctx, "DELETE", uri, post, dest, options...)
+}
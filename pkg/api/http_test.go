@@ -0,0 +1,440 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer starts a server answering every request with a small JSON document and x-ratelimit
+// headers, accepting any of the given API keys
+func newTestServer(t *testing.T, apiKeys ...string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized := false
+		for _, key := range apiKeys {
+			if r.Header.Get("Authorization") == "Bearer "+key {
+				authorized = true
+			}
+		}
+		if !authorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"title":"Unauthorized"}`)
+			return
+		}
+
+		resource := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/")[0]
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("x-ratelimit-limit", "1000")
+		w.Header().Set("x-ratelimit-remaining", "900")
+		w.Header().Set("x-ratelimit-reset", "60")
+		w.Header().Set("x-ratelimit-resource", resource)
+		fmt.Fprintf(w, `{"path":%q}`, r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPAPIConcurrentSettings(t *testing.T) {
+	srv := newTestServer(t, "key-a", "key-b")
+
+	g, err := New(srv.URL, "key-a", WithRateLimit(60000), WithLimiterMode(LimiterBlock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	const workers, requests = 8, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*requests)
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range requests {
+				uri := []string{"/api/v2/assets", "/api/v2/executions", "/api/v2/agent_logs"}[(w+i)%3]
+				var resp struct{ Path string }
+				if _, err := g.GetJSON(ctx, uri, &resp); err != nil {
+					errs <- err
+					continue
+				}
+				if resp.Path != uri {
+					errs <- fmt.Errorf("response for %s has path %s", uri, resp.Path)
+				}
+			}
+		}()
+	}
+
+	// Change every setting while the requests are in flight
+	var settingsWG sync.WaitGroup
+	stop := make(chan struct{})
+	settingsWG.Add(1)
+	go func() {
+		defer settingsWG.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			g.SetRateLimit([]int{60000, 120000}[i%2])
+			g.SetLimiterMode([]LimiterMode{LimiterBlock, LimiterBoundedWait}[i%2], DefaultMaxRateLimitWait)
+			g.SetAPIKey([]string{"key-a", "key-b"}[i%2])
+			if err := g.SetBaseURL(srv.URL); err != nil {
+				errs <- err
+			}
+			g.SetRetryPolicy(DefaultRetryPolicy())
+			_ = g.RateInfo()
+			_, _ = g.ResourceRateInfo("assets")
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	settingsWG.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if info, ok := g.ResourceRateInfo("executions"); !ok || info.Limit != 1000 {
+		t.Errorf("ResourceRateInfo(executions) = %+v, %v, want limit 1000", info, ok)
+	}
+}
+
+func TestHTTPAPIConcurrentUse(t *testing.T) {
+	srv := newTestServer(t, "key")
+
+	g, err := New(srv.URL, "key", WithRateLimit(60000))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var calls atomic.Int64
+	counting := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			calls.Add(1)
+			return next(ctx, req)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			g.Use(counting)
+		}()
+		go func() {
+			defer wg.Done()
+			var resp map[string]any
+			if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every middleware added before a request runs for it, so at most 8 middleware ran for each of the 8 requests
+	if n := calls.Load(); n > 64 {
+		t.Errorf("middleware ran %d times, want at most 64", n)
+	}
+}
+
+func TestRateLimiterAcquireModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    LimiterMode
+		maxWait time.Duration
+		wantErr error
+	}{
+		{"failfast", LimiterFailFast, 0, ErrRateLimited},
+		{"bounded wait exceeded", LimiterBoundedWait, 10 * time.Millisecond, ErrRateLimited},
+		{"block past context deadline", LimiterBlock, 0, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1 request per minute, the second request needs to wait a minute
+			r := NewRateLimiter(1)
+			if err := r.Acquire(context.Background(), tt.mode, tt.maxWait); err != nil {
+				t.Fatalf("first Acquire() error = %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := r.Acquire(ctx, tt.mode, tt.maxWait)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("second Acquire() error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("second Acquire() took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimiterAcquireBlockCancelled(t *testing.T) {
+	r := NewRateLimiter(1)
+	if err := r.Acquire(context.Background(), LimiterBlock, 0); err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	if err := r.Acquire(ctx, LimiterBlock, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateLimiterAcquireCancelled(t *testing.T) {
+	r := NewRateLimiter(100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, mode := range []LimiterMode{LimiterBoundedWait, LimiterBlock, LimiterFailFast} {
+		if err := r.Acquire(ctx, mode, time.Second); !errors.Is(err, context.Canceled) {
+			t.Errorf("Acquire(%s) error = %v, want context.Canceled", mode, err)
+		}
+	}
+}
+
+func TestRateLimiterConcurrentFailFast(t *testing.T) {
+	const burst = 20
+	r := NewRateLimiter(burst)
+
+	var allowed, limited atomic.Int64
+	var wg sync.WaitGroup
+	for range burst * 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.Acquire(context.Background(), LimiterFailFast, 0)
+			switch {
+			case err == nil:
+				allowed.Add(1)
+			case errors.Is(err, ErrRateLimited):
+				limited.Add(1)
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The bucket refills at one token every 3 seconds, so at most one more request can get through
+	if n := allowed.Load(); n < burst || n > burst+1 {
+		t.Errorf("%d requests allowed, want %d", n, burst)
+	}
+	if allowed.Load()+limited.Load() != burst*3 {
+		t.Errorf("allowed %d + limited %d != %d", allowed.Load(), limited.Load(), burst*3)
+	}
+}
+
+func TestRateLimiterConcurrentSetLimit(t *testing.T) {
+	r := NewRateLimiter(60000)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if err := r.Acquire(context.Background(), LimiterBlock, 0); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				r.SetLimit(60000 + (i+j)%2*60000)
+				_ = r.Limit()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if limit := r.Limit(); limit != 60000 && limit != 120000 {
+		t.Errorf("Limit() = %d", limit)
+	}
+}
+
+func TestRateTrackerBlocksAfterRateLimit(t *testing.T) {
+	tracker := newRateTracker(true)
+	tracker.observe("/api/v2/executions/e1/report", http.StatusTooManyRequests, RateInfo{Limit: 10, RetryAfter: 60, Resource: "executions"})
+
+	// Other requests to the same collection share the bucket
+	err := tracker.acquire(context.Background(), "/api/v2/executions", LimiterFailFast, 0)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("acquire() error = %v, want ErrRateLimited", err)
+	}
+
+	// Requests to other collections are not affected
+	if err := tracker.acquire(context.Background(), "/api/v2/assets", LimiterFailFast, 0); err != nil {
+		t.Errorf("acquire() for other resource error = %v", err)
+	}
+
+	// A successful response with budget left unblocks the resource
+	tracker.observe("/api/v2/executions", http.StatusOK, RateInfo{Limit: 10, Remaining: 10, Reset: 60, Resource: "executions"})
+	if err := tracker.acquire(context.Background(), "/api/v2/executions", LimiterFailFast, 0); err != nil {
+		t.Errorf("acquire() after reset error = %v", err)
+	}
+}
+
+func TestRateTrackerConcurrentObserveAcquire(t *testing.T) {
+	tracker := newRateTracker(true)
+	uris := []string{"/api/v2/assets", "/api/v2/executions", "/api/v2/agent_logs/x"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				uri := uris[(i+j)%len(uris)]
+				// Alternate between a low budget, which paces requests, and a full one
+				remaining := 400 + (j%2)*600
+				tracker.observe(uri, http.StatusOK, RateInfo{Limit: 1000, Remaining: remaining, Reset: 1, Resource: uri})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				err := tracker.acquire(ctx, uris[(i+j)%len(uris)], LimiterBoundedWait, time.Second)
+				if err != nil && !errors.Is(err, ErrRateLimited) {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if info := tracker.latestInfo(); info.Limit != 1000 {
+		t.Errorf("latestInfo() = %+v", info)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrApiKeyInvalid, ErrForbidden, ErrNotFound, ErrConflict, ErrRateLimited, ErrServerError}
+
+	tests := []struct {
+		status int
+		want   error // The only sentinel matched, nil for none
+	}{
+		{http.StatusBadRequest, nil},
+		{http.StatusUnauthorized, ErrApiKeyInvalid},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusServiceUnavailable, ErrServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-1")
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"title":"Failed","detail":"something went wrong","errors":[{"name":"id","reason":"invalid"}]}`)
+			}))
+			defer srv.Close()
+
+			g, err := New(srv.URL, "key", WithRetryPolicy(NoRetryPolicy()), WithAdaptiveRateLimit(false))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resp map[string]any
+			_, err = g.GetJSON(context.Background(), "/api/v2/assets", &resp)
+
+			// The error is matched through wrapping as well
+			err = fmt.Errorf("failed to list assets: %w", err)
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(err, %v) = %t", sentinel, got)
+				}
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As() failed for %v", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.RequestID != "req-1" || apiErr.RetryAfter != 7 || apiErr.Header.Get("Retry-After") != "7" {
+				t.Errorf("APIError = %+v, want the status, request ID, Retry-After and headers of the response", apiErr)
+			}
+			if apiErr.Title != "Failed" || apiErr.Detail != "something went wrong" || len(apiErr.Errors) != 1 {
+				t.Errorf("APIError = %+v, want the decoded payload", apiErr)
+			}
+		})
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		header     http.Header
+		body       string
+		wantDetail string
+		wantError  string
+	}{
+		{
+			name:      "empty body",
+			status:    http.StatusNotFound,
+			wantError: "Not Found (status 404)",
+		},
+		{
+			name:       "body that is not JSON",
+			status:     http.StatusBadGateway,
+			body:       "  <html>bad gateway</html>\n",
+			wantDetail: "<html>bad gateway</html>",
+			wantError:  "Bad Gateway: <html>bad gateway</html> (status 502)",
+		},
+		{
+			name:       "payload with fields and request ID",
+			status:     http.StatusBadRequest,
+			header:     http.Header{"X-Request-Id": {"abc"}, "X-Ratelimit-Retry-After": {"3"}},
+			body:       `{"title":"Invalid","detail":"bad input","errors":[{"name":"size","reason":"too large"}]}`,
+			wantDetail: "bad input",
+			wantError:  "Invalid: bad input [size: too large]: retry after 3 seconds (status 400, request id abc)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError(tt.status, tt.header, []byte(tt.body))
+			if err.StatusCode != tt.status || err.Detail != tt.wantDetail {
+				t.Errorf("newAPIError() = %+v", err)
+			}
+			if got := err.Error(); got != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Redacted replaces secret values in log output and recorded cassettes
+const Redacted = "[REDACTED]"
+
+// maxLoggedBody is the largest request or response body included in debug logs
+const maxLoggedBody = 4096
+
+// sensitiveKeys are JSON keys and query params whose values are never logged, compared case-insensitively.
+// apikey covers Asset.APIKey and environ covers SystemProcess.Environ.
+var sensitiveKeys = map[string]bool{
+	"apikey":        true,
+	"api_key":       true,
+	"authorization": true,
+	"environ":       true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// IsSensitiveKey reports whether values stored under a JSON key, query param or header must be redacted
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// RedactJSON returns a copy of a JSON document with the values of sensitive keys replaced.
+// Documents that are not valid JSON or contain no sensitive keys are returned unchanged, so
+// numbers, key order and formatting are only rewritten when something was redacted.
+func RedactJSON(body []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return body
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		// Trailing data after the document
+		return body
+	}
+
+	if !redactValue(doc) {
+		return body
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// redactValue replaces sensitive entries of a decoded JSON value in place and reports whether any was found
+func redactValue(v any) bool {
+	redactedAny := false
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			if IsSensitiveKey(k) {
+				val[k] = Redacted
+				redactedAny = true
+				continue
+			}
+			if redactValue(child) {
+				redactedAny = true
+			}
+		}
+	case []any:
+		for _, child := range val {
+			if redactValue(child) {
+				redactedAny = true
+			}
+		}
+	}
+	return redactedAny
+}
+
+// redactParams returns a copy of the query params with sensitive values replaced
+func redactParams(params map[string]string) map[string]string {
+	out := make(map[string]string, len(params))
+	for k, v := range params {
+		if IsSensitiveKey(k) {
+			v = Redacted
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// redactHeaders returns a copy of the headers with credentials replaced
+func redactHeaders(headers http.Header) http.Header {
+	out := headers.Clone()
+	for k := range out {
+		if IsSensitiveKey(k) || strings.EqualFold(k, "Cookie") || strings.EqualFold(k, "X-Api-Key") {
+			out[k] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// loggedBody prepares a body for debug logging
+func loggedBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	body = RedactJSON(body)
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactedURL returns the request URL with sensitive query params replaced
+func redactedURL(req *Request) string {
+	r := *req
+	r.Params = redactParams(req.Params)
+	return r.URL()
+}
+
+// LoggingMiddleware logs every API call with its method, URL, params, status, latency and rate limit info.
+// The Authorization header and sensitive fields such as API keys and process environments are redacted.
+// Bodies are only logged when the logger is enabled for debug.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			latency := time.Since(start)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", redactedURL(req)),
+				slog.Any("params", redactParams(req.Params)),
+				slog.Duration("latency", latency),
+			}
+			if len(req.Headers) > 0 {
+				attrs = append(attrs, slog.Any("headers", redactHeaders(req.Headers)))
+			}
+
+			debug := logger.Enabled(ctx, slog.LevelDebug)
+			if debug && len(req.Body) > 0 {
+				attrs = append(attrs, slog.String("request_body", loggedBody(req.Body)))
+			}
+
+			if resp != nil {
+				attrs = append(attrs,
+					slog.Int("status", resp.StatusCode),
+					slog.Group("ratelimit",
+						slog.Int("limit", resp.RateInfo.Limit),
+						slog.Int("remaining", resp.RateInfo.Remaining),
+						slog.Int64("reset", resp.RateInfo.Reset),
+						slog.String("resource", resp.RateInfo.Resource),
+					),
+				)
+				if requestID := resp.Header.Get("X-Request-Id"); requestID != "" {
+					attrs = append(attrs, slog.String("request_id", requestID))
+				}
+				if debug && len(resp.Body) > 0 {
+					attrs = append(attrs, slog.String("response_body", loggedBody(resp.Body)))
+				}
+			}
+
+			level := slog.LevelDebug
+			msg := "api request"
+			if err != nil {
+				level = slog.LevelWarn
+				msg = "api request failed"
+				attrs = append(attrs, slog.String("error", err.Error()))
+			} else if resp != nil && resp.StatusCode >= http.StatusBadRequest {
+				level = slog.LevelWarn
+			}
+
+			logger.LogAttrs(ctx, level, msg, attrs...)
+
+			return resp, err
+		}
+	}
+}
+
+// WithLogger logs API traffic to logger, see LoggingMiddleware
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) error {
+		if logger == nil {
+			return nil
+		}
+		o.middleware = append(o.middleware, LoggingMiddleware(logger))
+		return nil
+	}
+}
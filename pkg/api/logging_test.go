@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "no secrets is unchanged",
+			body: `{"z": 1, "id": 12345678901234567890, "a": [1.50, {"b": null}]}`,
+			want: `{"z": 1, "id": 12345678901234567890, "a": [1.50, {"b": null}]}`,
+		},
+		{
+			name: "sensitive keys are redacted",
+			body: `{"name":"host","APIKey":"secret","nested":[{"token":"t"}]}`,
+			want: `{"APIKey":"[REDACTED]","name":"host","nested":[{"token":"[REDACTED]"}]}`,
+		},
+		{
+			name: "numbers are kept when redacting",
+			body: `{"id":12345678901234567890,"password":"p"}`,
+			want: `{"id":12345678901234567890,"password":"[REDACTED]"}`,
+		},
+		{
+			name: "invalid JSON is unchanged",
+			body: `not json {"apikey":"secret"}`,
+			want: `not json {"apikey":"secret"}`,
+		},
+		{
+			name: "trailing data is unchanged",
+			body: `{"apikey":"secret"} {}`,
+			want: `{"apikey":"secret"} {}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactJSON([]byte(tt.body))); got != tt.want {
+				t.Errorf("RedactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// newEchoServer returns a server answering every request with a body holding a secret
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, `{"id":"a1","apikey":"response-secret"}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// logRequest sends a request carrying secrets in its params, headers and body with a client of opts
+func logRequest(t *testing.T, srv *httptest.Server, opts ...Option) {
+	t.Helper()
+
+	g, err := New(srv.URL, "client-api-key", append([]Option{WithRetryPolicy(NoRetryPolicy())}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var resp map[string]any
+	_, err = g.PostJSON(context.Background(), "/api/v2/assets", map[string]string{"name": "host", "password": "body-secret"}, &resp, ReqOptions{
+		Params:  map[string]string{"size": "5", "token": "param-secret"},
+		Headers: map[string]string{"Authorization": "Bearer header-secret", "X-Trace": "t1"},
+	})
+	if err != nil {
+		t.Fatalf("PostJSON() error = %v", err)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	srv := newEchoServer(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logRequest(t, srv, WithLogger(logger))
+
+	out := buf.String()
+	for _, secret := range []string{"client-api-key", "header-secret", "body-secret", "param-secret", "response-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains %q: %s", secret, out)
+		}
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log output is not a single JSON entry: %v\n%s", err, out)
+	}
+	if entry["msg"] != "api request" || entry["method"] != http.MethodPost || entry["status"] != float64(http.StatusOK) || entry["request_id"] != "req-1" {
+		t.Errorf("log entry = %v", entry)
+	}
+	if params := entry["params"].(map[string]any); params["size"] != "5" || params["token"] != Redacted {
+		t.Errorf("params = %v, want the token redacted", params)
+	}
+	if headers := entry["headers"].(map[string]any); fmt.Sprint(headers["Authorization"]) != "["+Redacted+"]" || fmt.Sprint(headers["X-Trace"]) != "[t1]" {
+		t.Errorf("headers = %v, want the Authorization header redacted", headers)
+	}
+	if body := entry["request_body"].(string); !strings.Contains(body, `"password":"`+Redacted+`"`) || !strings.Contains(body, `"name":"host"`) {
+		t.Errorf("request body = %s, want the password Redacted", body)
+	}
+	if body := entry["response_body"].(string); !strings.Contains(body, `"apikey":"`+Redacted+`"`) {
+		t.Errorf("response body = %s, want the API key Redacted", body)
+	}
+}
+
+func TestLoggingMiddlewareWithoutDebug(t *testing.T) {
+	srv := newEchoServer(t)
+
+	// Successful requests are logged at debug level, so an info logger writes nothing
+	var buf bytes.Buffer
+	logRequest(t, srv, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	if buf.Len() != 0 {
+		t.Errorf("info logger wrote %s", buf.String())
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	srv := newEchoServer(t)
+
+	// Without WithLogger the client never logs, not even to the default logger
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	logRequest(t, srv)
+	logRequest(t, srv, WithLogger(nil))
+	if buf.Len() != 0 {
+		t.Errorf("client without WithLogger logged %s", buf.String())
+	}
+
+	logRequest(t, srv, WithLogger(slog.Default()))
+	if !strings.Contains(buf.String(), `"msg":"api request"`) {
+		t.Errorf("client with WithLogger logged %q", buf.String())
+	}
+}
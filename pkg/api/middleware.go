@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// operationKey is the context key holding the logical operation name
+type operationKey struct{}
+
+// WithOperation returns a context carrying the name of the logical SDK operation,
+// such as "executions.GetExecutionReport", for use by middleware
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns the operation name set by WithOperation, or an empty string
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// Request describes an outgoing API call as seen by middleware.
+// Middleware may modify Params, Headers and Body before calling the next handler.
+type Request struct {
+	Operation string // Logical SDK operation, e.g. executions.GetExecutionReport, if known
+	Method    string
+	URI       string            // Path relative to the base URL, e.g. /api/v2/executions
+	Base      *url.URL          // Base URL of the client
+	Params    map[string]string // Query parameters
+	Headers   http.Header       // Extra headers, applied after the default Authorization and User-Agent headers
+	Body      []byte
+	IsJSON    bool
+}
+
+// URL returns the resolved request URL including the query parameters
+func (r *Request) URL() string {
+	u := r.Base.ResolveReference(&url.URL{Path: r.URI})
+
+	if len(r.Params) > 0 {
+		q := u.Query()
+		for k, v := range r.Params {
+			q.Add(k, v)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	return u.String()
+}
+
+// Response describes the result of an API call as seen by middleware.
+// A Response may be returned together with an error, e.g. for 429 responses.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Header      http.Header
+	Body        []byte
+	RateInfo    RateInfo
+}
+
+// Handler performs an API call
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to observe or alter requests and responses
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the chain. The first registered middleware is the outermost one
+// and sees the request first; retries happen inside the chain.
+func (g *HTTPAPI) Use(middleware ...Middleware) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.middleware = append(g.middleware, middleware...)
+}
+
+// handler returns the terminal handler wrapped by the registered middleware
+func (g *HTTPAPI) handler() Handler {
+	g.mu.RLock()
+	middleware := g.middleware
+	g.mu.RUnlock()
+
+	h := Handler(g.send)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+// WithMiddleware adds middleware to the client, see HTTPAPI.Use
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) error {
+		o.middleware = append(o.middleware, middleware...)
+		return nil
+	}
+}
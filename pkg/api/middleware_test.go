@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// recorder returns middleware appending name to calls before and after the next handler
+func recorder(mu *sync.Mutex, calls *[]string, name string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			mu.Lock()
+			*calls = append(*calls, name+" before")
+			mu.Unlock()
+
+			resp, err := next(ctx, req)
+
+			mu.Lock()
+			*calls = append(*calls, name+" after")
+			mu.Unlock()
+			return resp, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	srv := newTestServer(t, "key")
+
+	var mu sync.Mutex
+	var calls []string
+	g, err := New(srv.URL, "key", WithMiddleware(recorder(&mu, &calls, "option")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Use(recorder(&mu, &calls, "first"), recorder(&mu, &calls, "second"))
+
+	var resp map[string]any
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+
+	// The first registered middleware is the outermost one
+	want := []string{"option before", "first before", "second before", "second after", "first after", "option after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	srv := newStatusServer(t)
+	g := newRetryClient(t, srv, NoRetryPolicy())
+
+	errBlocked := errors.New("blocked by policy")
+	var innerCalled bool
+	g.Use(
+		func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				if req.Method == http.MethodDelete {
+					return nil, errBlocked
+				}
+				return next(ctx, req)
+			}
+		},
+		func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				innerCalled = true
+				return next(ctx, req)
+			}
+		},
+	)
+
+	var resp map[string]any
+	if _, err := g.DeleteJSON(context.Background(), "/api/v2/assets/a1", nil, &resp); !errors.Is(err, errBlocked) {
+		t.Errorf("DeleteJSON() error = %v, want the middleware error", err)
+	}
+	if innerCalled || srv.requests.Load() != 0 {
+		t.Errorf("request reached the inner middleware or the server after short-circuiting")
+	}
+
+	// A middleware can also answer a request itself
+	g.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			return &Response{StatusCode: http.StatusOK, Body: []byte(`{"cached":true}`)}, nil
+		}
+	})
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil || resp["cached"] != true {
+		t.Errorf("GetJSON() = %v, %v, want the response of the middleware", resp, err)
+	}
+	if srv.requests.Load() != 0 {
+		t.Errorf("%d requests reached the server", srv.requests.Load())
+	}
+}
+
+func TestMiddlewareModifiesRequest(t *testing.T) {
+	srv := newStatusServer(t)
+	g := newRetryClient(t, srv, NoRetryPolicy())
+
+	var seen *Request
+	g.Use(
+		func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				req.Params["size"] = "5"
+				req.Headers.Set("X-Trace", "t1")
+				return next(ctx, req)
+			}
+		},
+		func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				seen = req
+				return next(ctx, req)
+			}
+		},
+	)
+
+	var resp map[string]any
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if seen == nil || seen.Headers.Get("X-Trace") != "t1" || seen.URL() != srv.URL+"/api/v2/assets?size=5" {
+		t.Errorf("inner middleware saw %+v", seen)
+	}
+}
+
+func TestOperationPropagation(t *testing.T) {
+	srv := newTestServer(t, "key")
+
+	var operation string
+	g, err := New(srv.URL, "key", WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if OperationFromContext(ctx) != req.Operation {
+				t.Errorf("context operation %q differs from request operation %q", OperationFromContext(ctx), req.Operation)
+			}
+			operation = req.Operation
+			return next(ctx, req)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resp map[string]any
+	ctx := WithOperation(context.Background(), "assets.GetAssets")
+	if _, err := g.GetJSON(ctx, "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if operation != "assets.GetAssets" {
+		t.Errorf("operation = %q, want assets.GetAssets", operation)
+	}
+
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if operation != "" {
+		t.Errorf("operation without WithOperation = %q, want none", operation)
+	}
+	if got := OperationFromContext(context.Background()); got != "" {
+		t.Errorf("OperationFromContext() = %q", got)
+	}
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// UserAgent is the User-Agent sent with every request, options may append a suffix to it
+const UserAgent = "attack-sdk-go"
+
+// Default client settings used by New when no option overrides them
+const (
+	DefaultTimeout             = 60 * time.Second
+	DefaultRateLimit           = 100 // requests per minute
+	DefaultMaxIdleConns        = 100
+	DefaultMaxConnsPerHost     = 10
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// Option configures an HTTPAPI created with New
+type Option func(*clientOptions) error
+
+// clientOptions holds the settings collected from the options passed to New
+type clientOptions struct {
+	timeout             time.Duration
+	timeoutSet          bool // WithTimeout was used, so the timeout also applies to a WithHTTPClient client
+	httpClient          *http.Client
+	transport           http.RoundTripper
+	rootCAs             *x509.CertPool
+	certificates        []tls.Certificate
+	proxy               *url.URL
+	userAgentSuffix     string
+	rateLimit           int
+	limiterMode         LimiterMode
+	maxWait             time.Duration
+	adaptive            bool
+	maxIdleConns        int
+	maxConnsPerHost     int
+	maxIdleConnsPerHost int
+	retryPolicy         RetryPolicy
+	middleware          []Middleware
+}
+
+// New creates a new API client configured by the given options.
+// Without options it behaves like NewHTTPAPI: 60s timeout, 10 connections per host,
+// 100 reqs/min rate limit and the default retry policy.
+func New(baseURL, apiKey string, opts ...Option) (*HTTPAPI, error) {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	o := clientOptions{
+		timeout:             DefaultTimeout,
+		rateLimit:           DefaultRateLimit,
+		limiterMode:         LimiterBoundedWait,
+		maxWait:             DefaultMaxRateLimitWait,
+		adaptive:            true,
+		maxIdleConns:        DefaultMaxIdleConns,
+		maxConnsPerHost:     DefaultMaxConnsPerHost,
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		retryPolicy:         DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+
+	userAgent := UserAgent
+	if o.userAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", UserAgent, o.userAgentSuffix)
+	}
+
+	return &HTTPAPI{
+		BaseURL:     baseURL,
+		baseURL:     parsedURL,
+		client:      o.buildClient(),
+		APIKey:      apiKey,
+		userAgent:   userAgent,
+		rateLimiter: NewRateLimiter(o.rateLimit),
+		limiterMode: o.limiterMode,
+		maxWait:     o.maxWait,
+		rates:       newRateTracker(o.adaptive),
+		retryPolicy: o.retryPolicy,
+		middleware:  o.middleware,
+	}, nil
+}
+
+// buildClient returns the http.Client described by the options
+func (o *clientOptions) buildClient() *http.Client {
+	if o.httpClient != nil {
+		client := *o.httpClient
+		if o.timeoutSet {
+			client.Timeout = o.timeout
+		}
+		if client.Transport == nil {
+			client.Transport = o.buildTransport()
+		}
+		return &client
+	}
+
+	return &http.Client{Timeout: o.timeout, Transport: o.buildTransport()}
+}
+
+// buildTransport returns the custom transport if one was set, or a tuned http.Transport otherwise
+func (o *clientOptions) buildTransport() http.RoundTripper {
+	if o.transport != nil {
+		return o.transport
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        o.maxIdleConns,
+		MaxConnsPerHost:     o.maxConnsPerHost,
+		MaxIdleConnsPerHost: o.maxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}
+
+	if o.proxy != nil {
+		transport.Proxy = http.ProxyURL(o.proxy)
+	}
+
+	if o.rootCAs != nil || len(o.certificates) > 0 {
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			RootCAs:      o.rootCAs,
+			Certificates: o.certificates,
+		}
+	}
+
+	return transport
+}
+
+// WithTimeout sets the overall timeout of each HTTP request. It also overrides the timeout of
+// a client passed to WithHTTPClient, regardless of the order of the options.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		o.timeout = timeout
+		o.timeoutSet = true
+		return nil
+	}
+}
+
+// WithHTTPClient uses a copy of the given client for requests. If the client has no transport
+// the default tuned transport (including TLS and proxy options) is used. The client keeps its own
+// timeout unless WithTimeout is also given.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) error {
+		if client == nil {
+			return errors.New("http client must not be nil")
+		}
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithTransport replaces the default transport. TLS, proxy and connection pool options
+// are ignored when a custom transport is set.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		if transport == nil {
+			return errors.New("transport must not be nil")
+		}
+		o.transport = transport
+		return nil
+	}
+}
+
+// WithCABundle trusts the PEM encoded certificates in addition to the system roots
+func WithCABundle(pemCerts []byte) Option {
+	return func(o *clientOptions) error {
+		if o.rootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			o.rootCAs = pool
+		}
+
+		if !o.rootCAs.AppendCertsFromPEM(pemCerts) {
+			return errors.New("no valid certificates found in CA bundle")
+		}
+		return nil
+	}
+}
+
+// WithCABundleFile trusts the PEM encoded certificates in the file in addition to the system roots
+func WithCABundleFile(path string) Option {
+	return func(o *clientOptions) error {
+		pemCerts, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle '%s': %w", path, err)
+		}
+		return WithCABundle(pemCerts)(o)
+	}
+}
+
+// WithClientCertificate presents the certificate for mutual TLS
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *clientOptions) error {
+		o.certificates = append(o.certificates, cert)
+		return nil
+	}
+}
+
+// WithClientCertificateFile loads a PEM encoded certificate and key pair for mutual TLS
+func WithClientCertificateFile(certFile, keyFile string) Option {
+	return func(o *clientOptions) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		o.certificates = append(o.certificates, cert)
+		return nil
+	}
+}
+
+// WithProxy routes all requests through the given HTTP proxy instead of the environment proxy settings
+func WithProxy(proxyURL string) Option {
+	return func(o *clientOptions) error {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+		o.proxy = parsed
+		return nil
+	}
+}
+
+// WithUserAgent appends a suffix such as "my-service/1.2" to the User-Agent header
+func WithUserAgent(suffix string) Option {
+	return func(o *clientOptions) error {
+		o.userAgentSuffix = suffix
+		return nil
+	}
+}
+
+// WithRateLimit sets the client side rate limit in requests per minute
+func WithRateLimit(requestsPerMinute int) Option {
+	return func(o *clientOptions) error {
+		if requestsPerMinute <= 0 {
+			return errors.New("rate limit must be positive")
+		}
+		o.rateLimit = requestsPerMinute
+		return nil
+	}
+}
+
+// WithLimiterMode sets how requests wait when the client side rate limit is exhausted
+func WithLimiterMode(mode LimiterMode) Option {
+	return func(o *clientOptions) error {
+		o.limiterMode = mode
+		return nil
+	}
+}
+
+// WithMaxRateLimitWait sets the longest a request waits for a token in LimiterBoundedWait mode
+func WithMaxRateLimitWait(maxWait time.Duration) Option {
+	return func(o *clientOptions) error {
+		if maxWait < 0 {
+			return errors.New("max rate limit wait must not be negative")
+		}
+		o.maxWait = maxWait
+		return nil
+	}
+}
+
+// WithAdaptiveRateLimit enables or disables pacing requests from the x-ratelimit headers
+// the server sends on every response. Rate limit headers are tracked either way.
+func WithAdaptiveRateLimit(enabled bool) Option {
+	return func(o *clientOptions) error {
+		o.adaptive = enabled
+		return nil
+	}
+}
+
+// WithConnectionPool sets the idle connection pool size and the maximum connections per host
+func WithConnectionPool(maxIdleConns, maxConnsPerHost int) Option {
+	return func(o *clientOptions) error {
+		if maxIdleConns < 0 || maxConnsPerHost < 0 {
+			return errors.New("connection pool sizes must not be negative")
+		}
+		o.maxIdleConns = maxIdleConns
+		o.maxConnsPerHost = maxConnsPerHost
+		o.maxIdleConnsPerHost = maxConnsPerHost
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy used for requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = policy
+		return nil
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTimeoutWithHTTPClient(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want time.Duration
+	}{
+		{"default", nil, DefaultTimeout},
+		{"timeout", []Option{WithTimeout(5 * time.Second)}, 5 * time.Second},
+		{"client keeps its timeout", []Option{WithHTTPClient(&http.Client{Timeout: 7 * time.Second})}, 7 * time.Second},
+		{"timeout after client", []Option{WithHTTPClient(&http.Client{Timeout: 7 * time.Second}), WithTimeout(3 * time.Second)}, 3 * time.Second},
+		{"timeout before client", []Option{WithTimeout(3 * time.Second), WithHTTPClient(&http.Client{Timeout: 7 * time.Second})}, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New("https://example.com", "key", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if g.client.Timeout != tt.want {
+				t.Errorf("client timeout = %v, want %v", g.client.Timeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDoesNotModifyHTTPClient(t *testing.T) {
+	client := &http.Client{Timeout: 7 * time.Second}
+	if _, err := New("https://example.com", "key", WithHTTPClient(client), WithTimeout(time.Second)); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if client.Timeout != 7*time.Second || client.Transport != nil {
+		t.Errorf("caller's client was modified: %+v", client)
+	}
+}
@@ -0,0 +1,198 @@
+// Package otelapi instruments api.HTTPAPI with OpenTelemetry traces and metrics.
+//
+//	mw, err := otelapi.Middleware()
+//	if err != nil {
+//		return err
+//	}
+//	client, err := api.New(baseURL, apiKey, api.WithMiddleware(mw))
+package otelapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+)
+
+// ScopeName is the instrumentation scope used for the tracer and meter
+const ScopeName = "github.com/fourcorelabs/attack-sdk-go/pkg/api/otelapi"
+
+// Attribute keys recorded on spans and metrics
+const (
+	AttrOperation          = attribute.Key("fourcore.operation")
+	AttrRateLimitLimit     = attribute.Key("fourcore.ratelimit.limit")
+	AttrRateLimitRemaining = attribute.Key("fourcore.ratelimit.remaining")
+	AttrRateLimitResource  = attribute.Key("fourcore.ratelimit.resource")
+	AttrHTTPMethod         = attribute.Key("http.request.method")
+	AttrHTTPStatusCode     = attribute.Key("http.response.status_code")
+	AttrURLFull            = attribute.Key("url.full")
+	AttrURLPath            = attribute.Key("url.path")
+	AttrServerAddress      = attribute.Key("server.address")
+)
+
+// config holds the providers used by the middleware
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the middleware
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, defaults to the global one
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider, defaults to the global one
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagator sets the propagator used to inject trace context into request headers,
+// defaults to the global one
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// instruments holds the metric instruments recorded for every request
+type instruments struct {
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	rateLimited metric.Int64Counter
+}
+
+// Middleware returns an api.Middleware that creates a client span per request, named after the
+// logical SDK operation, and records request count, latency and 429 metrics
+func Middleware(opts ...Option) (api.Middleware, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	inst, err := newInstruments(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next api.Handler) api.Handler {
+		return func(ctx context.Context, req *api.Request) (*api.Response, error) {
+			operation := req.Operation
+			if operation == "" {
+				operation = fmt.Sprintf("HTTP %s", req.Method)
+			}
+
+			ctx, span := tracer.Start(ctx, operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(requestAttributes(req, operation)...),
+			)
+			defer span.End()
+
+			cfg.propagator.Inject(ctx, propagation.HeaderCarrier(req.Headers))
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(start)
+
+			metricAttrs := []attribute.KeyValue{
+				AttrOperation.String(operation),
+				AttrHTTPMethod.String(req.Method),
+			}
+
+			if resp != nil {
+				metricAttrs = append(metricAttrs, AttrHTTPStatusCode.Int(resp.StatusCode))
+				span.SetAttributes(
+					AttrHTTPStatusCode.Int(resp.StatusCode),
+					AttrRateLimitLimit.Int(resp.RateInfo.Limit),
+					AttrRateLimitRemaining.Int(resp.RateInfo.Remaining),
+					AttrRateLimitResource.String(resp.RateInfo.Resource),
+				)
+				if resp.StatusCode >= http.StatusBadRequest {
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			set := metric.WithAttributes(metricAttrs...)
+			inst.requests.Add(ctx, 1, set)
+			inst.duration.Record(ctx, elapsed.Seconds(), set)
+			if errors.Is(err, api.ErrRateLimited) || (resp != nil && resp.StatusCode == http.StatusTooManyRequests) {
+				inst.rateLimited.Add(ctx, 1, set)
+			}
+
+			return resp, err
+		}
+	}, nil
+}
+
+// newInstruments creates the metric instruments on meter
+func newInstruments(meter metric.Meter) (*instruments, error) {
+	requests, err := meter.Int64Counter("fourcore.client.requests",
+		metric.WithDescription("Number of FourCore API requests"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("fourcore.client.request.duration",
+		metric.WithDescription("Duration of FourCore API requests including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimited, err := meter.Int64Counter("fourcore.client.rate_limited",
+		metric.WithDescription("Number of FourCore API requests rejected by rate limiting"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruments{requests: requests, duration: duration, rateLimited: rateLimited}, nil
+}
+
+// requestAttributes returns the span attributes known before the request is sent
+func requestAttributes(req *api.Request, operation string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttrOperation.String(operation),
+		AttrHTTPMethod.String(req.Method),
+		AttrURLPath.String(req.URI),
+	}
+
+	if u, err := url.Parse(req.URL()); err == nil {
+		attrs = append(attrs, AttrServerAddress.String(u.Hostname()))
+		u.RawQuery = ""
+		attrs = append(attrs, AttrURLFull.String(u.String()))
+	}
+
+	return attrs
+}
@@ -0,0 +1,248 @@
+package otelapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+)
+
+// instrumentedClient returns a client for srv instrumented with in-memory span and metric exporters
+func instrumentedClient(t *testing.T, srv *httptest.Server) (*api.HTTPAPI, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := Middleware(WithTracerProvider(tp), WithMeterProvider(mp), WithPropagator(propagation.TraceContext{}))
+	if err != nil {
+		t.Fatalf("Middleware() error = %v", err)
+	}
+
+	client, err := api.New(srv.URL, "key", api.WithMiddleware(mw), api.WithRetryPolicy(api.NoRetryPolicy()))
+	if err != nil {
+		t.Fatalf("api.New() error = %v", err)
+	}
+	return client, recorder, reader
+}
+
+// collect returns the metrics recorded by reader by instrument name
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != ScopeName {
+			t.Errorf("scope = %s, want %s", sm.Scope.Name, ScopeName)
+		}
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+// sum returns the total of a counter
+func sum(t *testing.T, m metricdata.Metrics) int64 {
+	t.Helper()
+
+	data, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("%s is a %T, want an int64 sum", m.Name, m.Data)
+	}
+	var total int64
+	for _, dp := range data.DataPoints {
+		total += dp.Value
+	}
+	return total
+}
+
+// attr returns the value of an attribute of a span
+func attr(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddlewareSuccess(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("x-ratelimit-limit", "100")
+		w.Header().Set("x-ratelimit-remaining", "99")
+		w.Header().Set("x-ratelimit-resource", "executions")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, recorder, reader := instrumentedClient(t, srv)
+
+	ctx := api.WithOperation(context.Background(), "executions.GetExecutionReport")
+	var resp map[string]any
+	if _, err := client.GetJSON(ctx, "/api/v2/executions/e1/report", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "executions.GetExecutionReport" {
+		t.Errorf("span name = %q, want the operation name", span.Name())
+	}
+	if span.Status().Code != codes.Unset {
+		t.Errorf("span status = %v, want unset", span.Status())
+	}
+	if traceparent == "" {
+		t.Error("traceparent header was not injected")
+	}
+
+	for key, want := range map[attribute.Key]attribute.Value{
+		AttrOperation:          attribute.StringValue("executions.GetExecutionReport"),
+		AttrHTTPMethod:         attribute.StringValue(http.MethodGet),
+		AttrHTTPStatusCode:     attribute.IntValue(http.StatusOK),
+		AttrURLPath:            attribute.StringValue("/api/v2/executions/e1/report"),
+		AttrRateLimitLimit:     attribute.IntValue(100),
+		AttrRateLimitRemaining: attribute.IntValue(99),
+		AttrRateLimitResource:  attribute.StringValue("executions"),
+	} {
+		if got, ok := attr(span.Attributes(), key); !ok || got != want {
+			t.Errorf("span attribute %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	metrics := collect(t, reader)
+	if got := sum(t, metrics["fourcore.client.requests"]); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+	if _, ok := metrics["fourcore.client.rate_limited"]; ok {
+		t.Error("rate_limited recorded for a successful request")
+	}
+	duration, ok := metrics["fourcore.client.request.duration"].Data.(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 1 {
+		t.Errorf("request.duration = %+v, want one measurement", metrics["fourcore.client.request.duration"].Data)
+	}
+}
+
+func TestMiddlewareDefaultSpanName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, recorder, _ := instrumentedClient(t, srv)
+
+	var resp map[string]any
+	if _, err := client.PostJSON(context.Background(), "/api/v2/actions/run", nil, &resp); err != nil {
+		t.Fatalf("PostJSON() error = %v", err)
+	}
+
+	if spans := recorder.Ended(); len(spans) != 1 || spans[0].Name() != "HTTP POST" {
+		t.Errorf("spans = %v, want one span named HTTP POST", spans)
+	}
+}
+
+func TestMiddlewareRateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"title":"Too Many Requests","detail":"slow down"}`))
+	}))
+	defer srv.Close()
+
+	client, recorder, reader := instrumentedClient(t, srv)
+
+	ctx := api.WithOperation(context.Background(), "asset.GetAssets")
+	var resp map[string]any
+	_, err := client.GetJSON(ctx, "/api/v2/assets", &resp)
+
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, api.ErrRateLimited) {
+		t.Fatalf("GetJSON() error = %v, want a rate limited APIError", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "asset.GetAssets" {
+		t.Errorf("span name = %q", span.Name())
+	}
+	if span.Status().Code != codes.Error || span.Status().Description != apiErr.Error() {
+		t.Errorf("span status = %+v, want error %q", span.Status(), apiErr.Error())
+	}
+	if got, _ := attr(span.Attributes(), AttrHTTPStatusCode); got.AsInt64() != http.StatusTooManyRequests {
+		t.Errorf("status code attribute = %v, want 429", got.Emit())
+	}
+
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("span events = %+v, want the recorded error", events)
+	}
+	if msg, _ := attr(events[0].Attributes, "exception.message"); msg.AsString() != apiErr.Error() {
+		t.Errorf("exception.message = %q", msg.AsString())
+	}
+
+	metrics := collect(t, reader)
+	if got := sum(t, metrics["fourcore.client.requests"]); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+	if got := sum(t, metrics["fourcore.client.rate_limited"]); got != 1 {
+		t.Errorf("rate_limited = %d, want 1", got)
+	}
+	duration, ok := metrics["fourcore.client.request.duration"].Data.(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 1 {
+		t.Fatalf("request.duration = %+v, want one measurement", metrics["fourcore.client.request.duration"].Data)
+	}
+	if status, ok := duration.DataPoints[0].Attributes.Value(AttrHTTPStatusCode); !ok || status.AsInt64() != http.StatusTooManyRequests {
+		t.Errorf("request.duration status attribute = %v, want 429", status.Emit())
+	}
+}
+
+func TestMiddlewareServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"title":"Internal Server Error"}`))
+	}))
+	defer srv.Close()
+
+	client, recorder, reader := instrumentedClient(t, srv)
+
+	var resp map[string]any
+	if _, err := client.GetJSON(context.Background(), "/api/v2/assets", &resp); !errors.Is(err, api.ErrServerError) {
+		t.Fatalf("GetJSON() error = %v, want ErrServerError", err)
+	}
+
+	// The status is only turned into an APIError after the middleware chain, so the span carries the status code
+	if spans := recorder.Ended(); len(spans) != 1 || spans[0].Status().Code != codes.Error {
+		t.Errorf("spans = %v, want one span with an error status", spans)
+	}
+
+	metrics := collect(t, reader)
+	if _, ok := metrics["fourcore.client.rate_limited"]; ok {
+		t.Error("rate_limited recorded for a server error")
+	}
+}
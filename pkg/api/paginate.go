@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size used by iterators when the options leave Size unset
+const DefaultPageSize = 100
+
+// PageFunc fetches the page of at most size items starting at offset.
+// total is the number of items available across all pages, or 0 if the endpoint does not report it.
+type PageFunc[T any] func(ctx context.Context, offset, size int) (items []T, total int, err error)
+
+// pageOptions configures Paginate
+type pageOptions struct {
+	prefetch bool
+}
+
+// PageOption configures an auto-paginating iterator
+type PageOption func(*pageOptions)
+
+// WithPrefetch fetches the next page concurrently while the current one is being consumed
+func WithPrefetch() PageOption {
+	return func(o *pageOptions) {
+		o.prefetch = true
+	}
+}
+
+// pageResult is a fetched page
+type pageResult[T any] struct {
+	items []T
+	total int
+	err   error
+}
+
+// Paginate returns an iterator over every item from offset onwards, fetching pages of size
+// items lazily as the caller ranges over it. Iteration stops after the last page, on the first
+// error, which is yielded with the zero value, or when ctx is cancelled.
+func Paginate[T any](ctx context.Context, offset, size int, fetch PageFunc[T], opts ...PageOption) iter.Seq2[T, error] {
+	var cfg pageOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		get := func(offset int) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			load := func() {
+				items, total, err := fetch(ctx, offset, size)
+				ch <- pageResult[T]{items: items, total: total, err: err}
+			}
+			if cfg.prefetch {
+				go load()
+			} else {
+				load()
+			}
+			return ch
+		}
+
+		next := get(offset)
+		for {
+			var page pageResult[T]
+			select {
+			case <-ctx.Done():
+				yield(zero, ctx.Err())
+				return
+			case page = <-next:
+			}
+
+			if page.err != nil {
+				yield(zero, page.err)
+				return
+			}
+
+			offset += len(page.items)
+			more := len(page.items) > 0 && offset < page.total
+			if page.total <= 0 {
+				more = len(page.items) >= size
+			}
+
+			if more && cfg.prefetch {
+				next = get(offset)
+			}
+
+			for _, item := range page.items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !more {
+				return
+			}
+			if !cfg.prefetch {
+				next = get(offset)
+			}
+		}
+	}
+}
+
+// Collect drains an iterator into a slice, stopping at the first error
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
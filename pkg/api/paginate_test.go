@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pager serves a fixed list of items, recording the offsets it was asked for
+type pager struct {
+	mu      sync.Mutex
+	items   []int
+	total   func(offset int) int // Reported total, defaults to len(items)
+	failAt  int                  // Offset whose fetch fails, -1 for none
+	offsets []int
+}
+
+func newPager(n int) *pager {
+	p := &pager{failAt: -1}
+	for i := range n {
+		p.items = append(p.items, i)
+	}
+	return p
+}
+
+func (p *pager) fetch(ctx context.Context, offset, size int) ([]int, int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.offsets = append(p.offsets, offset)
+	if offset == p.failAt {
+		return []int{-1}, 0, errors.New("page failed")
+	}
+
+	total, limit := len(p.items), len(p.items)
+	if p.total != nil {
+		total = p.total(offset)
+	}
+	if total > 0 {
+		limit = min(limit, max(total, offset))
+	}
+	end := min(offset+size, limit)
+	return slices.Clone(p.items[offset:end]), total, nil
+}
+
+func (p *pager) fetched() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.offsets)
+}
+
+func TestPaginate(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		var opts []PageOption
+		if prefetch {
+			opts = append(opts, WithPrefetch())
+		}
+
+		tests := []struct {
+			name        string
+			items       int
+			offset      int
+			size        int
+			total       func(offset int) int
+			want        int
+			wantOffsets []int
+		}{
+			{name: "several pages", items: 7, size: 3, want: 7, wantOffsets: []int{0, 3, 6}},
+			{name: "exact pages", items: 6, size: 3, want: 6, wantOffsets: []int{0, 3}},
+			{name: "from an offset", items: 7, offset: 5, size: 3, want: 2, wantOffsets: []int{5}},
+			{name: "empty", items: 0, size: 3, want: 0, wantOffsets: []int{0}},
+			{name: "default page size", items: DefaultPageSize + 1, want: DefaultPageSize + 1, wantOffsets: []int{0, DefaultPageSize}},
+			{
+				name:  "count shrinks between pages",
+				items: 10, size: 2,
+				// Items are deleted after the first page, leaving 3 when the second one is fetched
+				total: func(offset int) int {
+					if offset == 0 {
+						return 10
+					}
+					return 3
+				},
+				want: 3, wantOffsets: []int{0, 2},
+			},
+			{
+				name:  "no count reported",
+				items: 5, size: 2,
+				total: func(int) int { return 0 },
+				want:  5, wantOffsets: []int{0, 2, 4},
+			},
+		}
+
+		for _, tt := range tests {
+			name := tt.name
+			if prefetch {
+				name += " with prefetch"
+			}
+			t.Run(name, func(t *testing.T) {
+				p := newPager(tt.items)
+				p.total = tt.total
+
+				items, err := Collect(Paginate(context.Background(), tt.offset, tt.size, p.fetch, opts...))
+				if err != nil {
+					t.Fatalf("Collect() error = %v", err)
+				}
+				if len(items) != tt.want {
+					t.Errorf("Collect() = %d items, want %d", len(items), tt.want)
+				}
+				for i, item := range items {
+					if item != tt.offset+i {
+						t.Fatalf("item %d = %d, want %d", i, item, tt.offset+i)
+					}
+				}
+				if got := p.fetched(); !slices.Equal(got, tt.wantOffsets) {
+					t.Errorf("fetched offsets %v, want %v", got, tt.wantOffsets)
+				}
+			})
+		}
+	}
+}
+
+func TestPaginateErrorMidway(t *testing.T) {
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		p := newPager(10)
+		p.failAt = 4
+
+		items, err := Collect(Paginate(context.Background(), 0, 2, p.fetch, opts...))
+		if err == nil || err.Error() != "page failed" {
+			t.Fatalf("Collect() error = %v, want the page error", err)
+		}
+		// The items of the failed page are dropped
+		if !slices.Equal(items, []int{0, 1, 2, 3}) {
+			t.Errorf("Collect() = %v, want the pages before the error", items)
+		}
+		if got := p.fetched(); !slices.Equal(got, []int{0, 2, 4}) {
+			t.Errorf("fetched offsets %v, want no fetch after the error", got)
+		}
+	}
+}
+
+func TestPaginateBreakStopsPrefetch(t *testing.T) {
+	started := make(chan struct{})
+	returned := make(chan error, 1)
+	fetch := func(ctx context.Context, offset, size int) ([]int, int, error) {
+		if offset == 0 {
+			return []int{0, 1}, 10, nil
+		}
+		// The prefetch of the second page blocks until the iterator cancels it
+		close(started)
+		<-ctx.Done()
+		returned <- ctx.Err()
+		return nil, 0, ctx.Err()
+	}
+
+	for item, err := range Paginate(context.Background(), 0, 2, fetch, WithPrefetch()) {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if item == 0 {
+			<-started
+			break
+		}
+	}
+
+	select {
+	case err := <-returned:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("prefetch context error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("prefetch goroutine still blocked after breaking out of the loop")
+	}
+}
+
+func TestPaginateContextCancelled(t *testing.T) {
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := newPager(10)
+
+		var items []int
+		var gotErr error
+		for item, err := range Paginate(ctx, 0, 3, p.fetch, opts...) {
+			if err != nil {
+				gotErr = err
+				break
+			}
+			items = append(items, item)
+			if item == 1 {
+				cancel()
+			}
+		}
+		cancel()
+
+		if !errors.Is(gotErr, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", gotErr)
+		}
+		if !slices.Equal(items, []int{0, 1}) {
+			t.Errorf("items = %v, want none after the cancellation", items)
+		}
+	}
+}
+
+func TestPaginateCancelledWhileFetching(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetch := func(ctx context.Context, offset, size int) ([]int, int, error) {
+		if offset > 0 {
+			cancel()
+			<-ctx.Done()
+			return nil, 0, ctx.Err()
+		}
+		return []int{0, 1}, 4, nil
+	}
+
+	items, err := Collect(Paginate(ctx, 0, 2, fetch, WithPrefetch()))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect() error = %v, want context.Canceled", err)
+	}
+	if len(items) > 2 {
+		t.Errorf("Collect() = %v, want at most the first page", items)
+	}
+}
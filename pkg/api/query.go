@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// OrderByParam is the query param carrying multi-column ordering, e.g. created_at:desc,hostname:asc.
+// The API reference only documents the single direction order param, so the name of this param is
+// assumed; change it here if the API expects another one.
+const OrderByParam = "order_by"
+
+// Query builds the query params of a list request
+type Query map[string]string
+
+// NewQuery returns an empty Query
+func NewQuery() Query {
+	return Query{}
+}
+
+// Set sets a param, empty values are skipped
+func (q Query) Set(name, value string) Query {
+	if value != "" {
+		q[name] = value
+	}
+	return q
+}
+
+// SetInt sets an integer param
+func (q Query) SetInt(name string, value int) Query {
+	q[name] = strconv.Itoa(value)
+	return q
+}
+
+// SetTime sets a time param in RFC3339 format, zero times are skipped
+func (q Query) SetTime(name string, value time.Time) Query {
+	if !value.IsZero() {
+		q[name] = value.Format(time.RFC3339)
+	}
+	return q
+}
+
+// SetList sets a comma separated list param, empty lists are skipped
+func (q Query) SetList(name string, values []string) Query {
+	if len(values) > 0 {
+		q[name] = strings.Join(values, ",")
+	}
+	return q
+}
+
+// Page sets the size, offset and order params shared by every list endpoint
+func (q Query) Page(size, offset int, order string) Query {
+	q.SetInt("size", size)
+	q.SetInt("offset", offset)
+	q["order"] = order
+	return q
+}
+
+// OrderBy sets the order_by param from columns in priority order
+func (q Query) OrderBy(order ...models.OrderBy) Query {
+	if len(order) == 0 {
+		return q
+	}
+
+	columns := make([]string, 0, len(order))
+	for _, o := range order {
+		columns = append(columns, FormatOrderBy(o))
+	}
+	q[OrderByParam] = strings.Join(columns, ",")
+	return q
+}
+
+// Filter sets a param per filter, with multiple values comma separated.
+// Filters are applied after the typed options and override params of the same name.
+func (q Query) Filter(filters ...models.FilterBy) Query {
+	for _, f := range filters {
+		if f.Name != "" {
+			q.SetList(f.Name, f.Value)
+		}
+	}
+	return q
+}
+
+// Pagination sets the size, offset, order_by and filter params from a models.Pagination
+func (q Query) Pagination(p models.Pagination) Query {
+	q["size"] = strconv.FormatUint(p.Size, 10)
+	q["offset"] = strconv.FormatUint(p.Offset, 10)
+	return q.OrderBy(p.OrderQuery...).Filter(p.FilterQuery...)
+}
+
+// FormatOrderBy formats a column as name:asc or name:desc
+func FormatOrderBy(o models.OrderBy) string {
+	if o.Asc {
+		return o.Name + ":asc"
+	}
+	return o.Name + ":desc"
+}
+
+// ParseOrderBy parses a column in the form name, name:asc or name:desc. Columns default to descending.
+func ParseOrderBy(s string) (models.OrderBy, error) {
+	name, dir, _ := strings.Cut(strings.TrimSpace(s), ":")
+	if name == "" {
+		return models.OrderBy{}, fmt.Errorf("invalid order by '%s': missing column name", s)
+	}
+
+	switch strings.ToLower(dir) {
+	case "", "desc":
+		return models.OrderBy{Name: name}, nil
+	case "asc":
+		return models.OrderBy{Name: name, Asc: true}, nil
+	default:
+		return models.OrderBy{}, fmt.Errorf("invalid order by '%s': direction must be asc or desc", s)
+	}
+}
+
+// ParseFilterBy parses a filter in the form name=value1,value2
+func ParseFilterBy(s string) (models.FilterBy, error) {
+	name, value, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || value == "" {
+		return models.FilterBy{}, fmt.Errorf("invalid filter '%s': must be in the form name=value[,value...]", s)
+	}
+
+	return models.FilterBy{Name: name, Value: strings.Split(value, ",")}, nil
+}
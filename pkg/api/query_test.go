@@ -0,0 +1,101 @@
+package api
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    models.OrderBy
+		wantErr bool
+	}{
+		{in: "created_at", want: models.OrderBy{Name: "created_at"}},
+		{in: "created_at:desc", want: models.OrderBy{Name: "created_at"}},
+		{in: "created_at:asc", want: models.OrderBy{Name: "created_at", Asc: true}},
+		{in: "hostname:ASC", want: models.OrderBy{Name: "hostname", Asc: true}},
+		{in: "  hostname:desc ", want: models.OrderBy{Name: "hostname"}},
+		{in: "created_at:", want: models.OrderBy{Name: "created_at"}},
+		{in: "", wantErr: true},
+		{in: "   ", wantErr: true},
+		{in: ":asc", wantErr: true},
+		{in: "created_at:up", wantErr: true},
+		{in: "created_at:asc:desc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOrderBy(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOrderBy(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseOrderBy(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if !tt.wantErr {
+			if again, _ := ParseOrderBy(FormatOrderBy(got)); again != got {
+				t.Errorf("ParseOrderBy(FormatOrderBy(%+v)) = %+v", got, again)
+			}
+		}
+	}
+}
+
+func TestParseFilterBy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    models.FilterBy
+		wantErr bool
+	}{
+		{in: "platform=windows", want: models.FilterBy{Name: "platform", Value: []string{"windows"}}},
+		{in: "platform=windows,linux", want: models.FilterBy{Name: "platform", Value: []string{"windows", "linux"}}},
+		{in: " platform =windows", want: models.FilterBy{Name: "platform", Value: []string{"windows"}}},
+		{in: "query=a=b", want: models.FilterBy{Name: "query", Value: []string{"a=b"}}},
+		{in: "", wantErr: true},
+		{in: "platform", wantErr: true},
+		{in: "platform=", wantErr: true},
+		{in: "=windows", wantErr: true},
+		{in: " =windows", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFilterBy(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFilterBy(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got.Name != tt.want.Name || !slices.Equal(got.Value, tt.want.Value) {
+			t.Errorf("ParseFilterBy(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQueryOrderByAndFilter(t *testing.T) {
+	q := NewQuery().
+		Page(10, 20, "DESC").
+		Set("name", "ransom").
+		OrderBy(models.OrderBy{Name: "created_at"}, models.OrderBy{Name: "hostname", Asc: true}).
+		Filter(models.FilterBy{Name: "name", Value: []string{"a", "b"}}, models.FilterBy{Value: []string{"ignored"}})
+
+	want := Query{
+		"size":       "10",
+		"offset":     "20",
+		"order":      "DESC",
+		OrderByParam: "created_at:desc,hostname:asc",
+		"name":       "a,b",
+	}
+	if len(q) != len(want) {
+		t.Errorf("Query = %v, want %v", q, want)
+	}
+	for k, v := range want {
+		if q[k] != v {
+			t.Errorf("Query[%s] = %q, want %q", k, q[k], v)
+		}
+	}
+
+	if q := NewQuery().OrderBy(); len(q) != 0 {
+		t.Errorf("OrderBy() without columns = %v, want no params", q)
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried by HTTPAPI
+type RetryPolicy struct {
+	MaxAttempts          int           // Total attempts including the first one, values <= 1 disable retries
+	InitialBackoff       time.Duration // Delay before the first retry
+	MaxBackoff           time.Duration // Upper bound for the computed backoff delay
+	Multiplier           float64       // Growth factor applied to the delay after every attempt
+	Jitter               float64       // Fraction (0-1) of the delay that is randomized
+	MaxRetryAfter        time.Duration // Largest server requested delay we are willing to honor, 0 means no limit
+	RetryableStatusCodes []int         // Response status codes that trigger a retry
+	RetryNonIdempotent   bool          // Allow retrying POST/PATCH requests such as chain runs
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxRetryAfter:  2 * time.Minute,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// NoRetryPolicy returns a policy that never retries
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// SetRetryPolicy replaces the retry policy used for subsequent requests
+func (g *HTTPAPI) SetRetryPolicy(policy RetryPolicy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.retryPolicy = policy
+}
+
+// isIdempotent reports whether a request with the given method can be safely repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry decides if another attempt should be made after the given result
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, statusCode int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+
+	if err != nil && statusCode == 0 {
+		// Errors raised by the local rate limiter or a cancelled context are not transient
+		if errors.Is(err, ErrRateLimited) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return true
+	}
+
+	return slices.Contains(p.RetryableStatusCodes, statusCode)
+}
+
+// backoff returns the delay before the next attempt, preferring the server provided retry-after value
+func (p RetryPolicy) backoff(attempt int, rateInfo RateInfo) (time.Duration, bool) {
+	if rateInfo.RetryAfter > 0 {
+		retryAfter := time.Duration(rateInfo.RetryAfter) * time.Second
+		if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay), true
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
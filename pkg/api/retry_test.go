@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// statusServer answers the n-th request with statuses[n], and with 200 once the statuses are used up.
+// A status given as a negative number is sent as a 429 with a Retry-After of -status seconds.
+type statusServer struct {
+	*httptest.Server
+	requests atomic.Int64
+}
+
+func newStatusServer(t *testing.T, statuses ...int) *statusServer {
+	t.Helper()
+
+	s := &statusServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(s.requests.Add(1)) - 1
+		status := http.StatusOK
+		if n < len(statuses) {
+			status = statuses[n]
+		}
+		if status < 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(-status))
+			status = http.StatusTooManyRequests
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"status":%d}`, status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// fastRetryPolicy is the default policy with millisecond backoffs
+func fastRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 10 * time.Millisecond
+	return p
+}
+
+// newRetryClient returns a client of srv with the given retry policy, without adaptive pacing so that
+// only the retry policy decides how long to wait
+func newRetryClient(t *testing.T, srv *statusServer, policy RetryPolicy, opts ...Option) *HTTPAPI {
+	t.Helper()
+
+	g, err := New(srv.URL, "key", append([]Option{WithRetryPolicy(policy), WithAdaptiveRateLimit(false)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return g
+}
+
+func TestRetryServerError(t *testing.T) {
+	srv := newStatusServer(t, http.StatusServiceUnavailable)
+	g := newRetryClient(t, srv, fastRetryPolicy())
+
+	var resp map[string]any
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if n := srv.requests.Load(); n != 2 {
+		t.Errorf("%d requests, want the 503 to be retried once", n)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	srv := newStatusServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	g := newRetryClient(t, srv, fastRetryPolicy())
+
+	var resp map[string]any
+	_, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp)
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("GetJSON() error = %v, want ErrServerError", err)
+	}
+	if n := srv.requests.Load(); n != 3 {
+		t.Errorf("%d requests, want MaxAttempts", n)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv := newStatusServer(t, -1)
+	g := newRetryClient(t, srv, fastRetryPolicy())
+
+	start := time.Now()
+	var resp map[string]any
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want the 1s Retry-After instead of the backoff", elapsed)
+	}
+	if n := srv.requests.Load(); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
+
+func TestRetryAfterAboveMax(t *testing.T) {
+	srv := newStatusServer(t, -600)
+	policy := fastRetryPolicy()
+	policy.MaxRetryAfter = time.Minute
+	g := newRetryClient(t, srv, policy)
+
+	start := time.Now()
+	var resp map[string]any
+	_, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) || apiErr.RetryAfter != 600 {
+		t.Errorf("GetJSON() error = %v, want the 429 with its Retry-After", err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d requests, want no retry", n)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("gave up after %v", elapsed)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryPost    bool
+		wantRequests int64
+		wantErr      bool
+	}{
+		{name: "POST is not retried by default", wantRequests: 1, wantErr: true},
+		{name: "POST is retried with RetryNonIdempotent", retryPost: true, wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newStatusServer(t, http.StatusServiceUnavailable)
+			policy := fastRetryPolicy()
+			policy.RetryNonIdempotent = tt.retryPost
+			g := newRetryClient(t, srv, policy)
+
+			var resp map[string]any
+			_, err := g.PostJSON(context.Background(), "/api/v2/actions/run", map[string]string{"id": "a"}, &resp)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PostJSON() error = %v, want error %t", err, tt.wantErr)
+			}
+			if n := srv.requests.Load(); n != tt.wantRequests {
+				t.Errorf("%d requests, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryNotOnLocalLimiter(t *testing.T) {
+	srv := newStatusServer(t)
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Minute
+	g := newRetryClient(t, srv, policy, WithRateLimit(1), WithLimiterMode(LimiterFailFast))
+
+	var resp map[string]any
+	if _, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp); err != nil {
+		t.Fatalf("first GetJSON() error = %v", err)
+	}
+
+	start := time.Now()
+	_, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second GetJSON() error = %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("local limiter error was retried, took %v", elapsed)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d requests, want only the first one", n)
+	}
+}
+
+func TestRetryNotOnCancelledContext(t *testing.T) {
+	srv := newStatusServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Minute
+	g := newRetryClient(t, srv, policy)
+
+	// The context is cancelled while waiting for the retry
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var resp map[string]any
+	_, err := g.GetJSON(ctx, "/api/v2/assets", &resp)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetJSON() error = %v, want context.DeadlineExceeded", err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+
+	// A context cancelled before the request is never sent
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.GetJSON(cancelled, "/api/v2/assets", &resp); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetJSON() with a cancelled context error = %v", err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d requests, want none with a cancelled context", n)
+	}
+}
+
+func TestNoRetryPolicy(t *testing.T) {
+	srv := newStatusServer(t, http.StatusServiceUnavailable)
+	g := newRetryClient(t, srv, NoRetryPolicy())
+
+	var resp map[string]any
+	_, err := g.GetJSON(context.Background(), "/api/v2/assets", &resp)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GetJSON() error = %v, want the 503", err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	p := DefaultRetryPolicy()
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		method     string
+		attempt    int
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"retryable status", http.MethodGet, 1, http.StatusServiceUnavailable, nil, true},
+		{"other status", http.MethodGet, 1, http.StatusInternalServerError, nil, false},
+		{"success", http.MethodGet, 1, http.StatusOK, nil, false},
+		{"last attempt", http.MethodGet, 3, http.StatusServiceUnavailable, nil, false},
+		{"non idempotent", http.MethodPost, 1, http.StatusServiceUnavailable, nil, false},
+		{"network error", http.MethodGet, 1, 0, errors.New("connection reset"), true},
+		{"local limiter", http.MethodGet, 1, 0, fmt.Errorf("%w: retry after 1.0 seconds", ErrRateLimited), false},
+		{"cancelled", http.MethodGet, 1, 0, context.Canceled, false},
+		{"deadline", http.MethodGet, 1, 0, context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		if got := p.shouldRetry(ctx, tt.method, tt.attempt, tt.statusCode, tt.err); got != tt.want {
+			t.Errorf("%s: shouldRetry() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2, MaxRetryAfter: time.Minute}
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		if got, ok := p.backoff(attempt, RateInfo{}); !ok || got != want {
+			t.Errorf("backoff(%d) = %v, %t, want %v", attempt, got, ok, want)
+		}
+	}
+
+	if got, ok := p.backoff(1, RateInfo{RetryAfter: 30}); !ok || got != 30*time.Second {
+		t.Errorf("backoff() with Retry-After = %v, %t, want 30s", got, ok)
+	}
+	if _, ok := p.backoff(1, RateInfo{RetryAfter: 61}); ok {
+		t.Error("backoff() with a Retry-After above MaxRetryAfter did not give up")
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		if got, _ := p.backoff(2, RateInfo{}); got < time.Second || got > 2*time.Second {
+			t.Fatalf("backoff() with jitter = %v, want between 1s and 2s", got)
+		}
+	}
+}
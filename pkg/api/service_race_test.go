@@ -0,0 +1,194 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/actions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/agentlog"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/asset"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/auditlog"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/chains"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/emailchains"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/mitre"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	agentlogmodels "github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
+	assetmodels "github.com/fourcorelabs/attack-sdk-go/pkg/models/asset"
+	auditlogmodels "github.com/fourcorelabs/attack-sdk-go/pkg/models/auditlog"
+	mitremodels "github.com/fourcorelabs/attack-sdk-go/pkg/models/mitre"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/wafchains"
+)
+
+// collect drains a paginating iterator, returning its first error
+func collect[T any](seq func(yield func(T, error) bool)) error {
+	for _, err := range seq {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestServicesConcurrentUse calls every service package from many goroutines on one shared client
+// while its settings change. Run it with -race.
+func TestServicesConcurrentUse(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+
+	srv.AddAsset(assetmodels.Asset{ID: "a1"})
+	srv.AddEmailAsset(assetmodels.EmailAsset{ID: "m1"})
+	srv.AddExecution(models.GetExecutionResponse{ID: "e1", Assets: []models.AssetExecutionDetails{{AssetID: "a1"}}})
+	srv.AddAssetAttack("a1", assetmodels.AssetAttack{ID: "at1"})
+	srv.AddAssetPack("a1", models.PackRun{ID: "p1"})
+	srv.AddAgentLog(agentlogmodels.AgentLog{ID: "l1", AssetID: "a1"})
+	srv.AddAuditLog(auditlogmodels.AuditLog{ID: "u1"})
+	srv.AddMitreTechnique(mitremodels.MitreTacticTechniqueWithActionAndStagers{TechniqueID: "T1003"})
+
+	client := srv.Client(api.WithRateLimit(60000), api.WithLimiterMode(api.LimiterBlock))
+	run := models.AttackRun{Assets: []string{"a1"}}
+
+	operations := map[string]func(ctx context.Context) error{
+		"executions.GetExecutions": func(ctx context.Context) error {
+			_, err := executions.GetExecutions(ctx, client, executions.ExecutionOpts{Size: 2})
+			return err
+		},
+		"executions.GetExecutionReport": func(ctx context.Context) error {
+			_, err := executions.GetExecutionReport(ctx, client, "e1")
+			return err
+		},
+		"executions.GetExecutionStepReport": func(ctx context.Context) error {
+			_, err := executions.GetExecutionStepReport(ctx, client, "e1")
+			return err
+		},
+		"executions.All": func(ctx context.Context) error {
+			return collect(executions.All(ctx, client, executions.ExecutionOpts{Size: 1}, api.WithPrefetch()))
+		},
+		"asset.GetAssets": func(ctx context.Context) error {
+			_, err := asset.GetAssets(ctx, client)
+			return err
+		},
+		"asset.GetAsset": func(ctx context.Context) error {
+			_, err := asset.GetAsset(ctx, client, "a1")
+			return err
+		},
+		"asset.EnableAsset": func(ctx context.Context) error {
+			_, err := asset.EnableAsset(ctx, client, "a1")
+			return err
+		},
+		"asset.SetAssetTags": func(ctx context.Context) error {
+			_, err := asset.SetAssetTags(ctx, client, "a1", map[string]string{"env": "test"})
+			return err
+		},
+		"asset.GetAssetAttacks": func(ctx context.Context) error {
+			_, err := asset.GetAssetAttacks(ctx, client, "a1", asset.GetAssetAttacksOpts{})
+			return err
+		},
+		"asset.AllAssetExecutions": func(ctx context.Context) error {
+			return collect(asset.AllAssetExecutions(ctx, client, "a1", asset.GetAssetExecutionsOpts{}))
+		},
+		"asset.GetAssetPacks": func(ctx context.Context) error {
+			_, err := asset.GetAssetPacks(ctx, client, "a1", asset.GetAssetExecutionsOpts{})
+			return err
+		},
+		"asset.GetEmailAssets": func(ctx context.Context) error {
+			_, err := asset.GetEmailAssets(ctx, client)
+			return err
+		},
+		"agentlog.GetAgentLogs": func(ctx context.Context) error {
+			_, err := agentlog.GetAgentLogs(ctx, client, agentlog.AgentLogOpts{})
+			return err
+		},
+		"agentlog.All": func(ctx context.Context) error {
+			return collect(agentlog.All(ctx, client, agentlog.AgentLogOpts{}))
+		},
+		"auditlog.GetAuditLogs": func(ctx context.Context) error {
+			_, err := auditlog.GetAuditLogs(ctx, client, auditlog.AuditLogOpts{})
+			return err
+		},
+		"auditlog.All": func(ctx context.Context) error {
+			return collect(auditlog.All(ctx, client, auditlog.AuditLogOpts{}))
+		},
+		"mitre.GetAllMitreCoverage": func(ctx context.Context) error {
+			_, err := mitre.GetAllMitreCoverage(ctx, client, 30)
+			return err
+		},
+		"mitre.GetMitreTechnique": func(ctx context.Context) error {
+			_, err := mitre.GetMitreTechnique(ctx, client, "T1003", 30)
+			return err
+		},
+		"chains.ExecuteEndpointChain": func(ctx context.Context) error {
+			_, err := chains.ExecuteEndpointChain(ctx, client, "c1", run)
+			return err
+		},
+		"actions.ExecuteEndpointAction": func(ctx context.Context) error {
+			_, err := actions.ExecuteEndpointAction(ctx, client, models.AttackRunActionsStagers{AttackRun: run, Actions: []string{"act-1"}})
+			return err
+		},
+		"emailchains.ExecuteEmailChain": func(ctx context.Context) error {
+			_, err := emailchains.ExecuteEmailChain(ctx, client, "c2", models.AttackRun{EmailAssets: []string{"m1"}})
+			return err
+		},
+		"wafchains.ExecuteWAFChain": func(ctx context.Context) error {
+			_, err := wafchains.ExecuteWAFChain(ctx, client, "c3", models.AttackRun{WafAssets: []string{"w1"}})
+			return err
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	const workers, rounds = 8, 3
+	errs := make(chan error, workers*rounds*len(operations))
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				for name, op := range operations {
+					if err := op(ctx); err != nil {
+						errs <- fmt.Errorf("%s: %w", name, err)
+					}
+				}
+			}
+		}()
+	}
+
+	// Change the client settings while the calls are in flight
+	stop := make(chan struct{})
+	var settingsWG sync.WaitGroup
+	settingsWG.Add(1)
+	go func() {
+		defer settingsWG.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			client.SetRateLimit([]int{60000, 120000}[i%2])
+			client.SetAPIKey(fakeserver.DefaultAPIKey)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	settingsWG.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// Every run created an execution next to the seeded one
+	if got, want := len(srv.Executions()), 1+4*workers*rounds; got != want {
+		t.Errorf("%d executions, want %d", got, want)
+	}
+}
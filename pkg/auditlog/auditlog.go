@@ -1,35 +1,53 @@
-package auditlog
-
-import (
-	"context"
-	"strconv"
-
-	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models/auditlog"
-)
-
-// AuditLogV2URI is the endpoint for the audit logs API.
-const AuditLogV2URI = "/api/v2/audit_logs"
-
-// AuditLogOpts represents options for listing audit logs.
-type AuditLogOpts struct {
-	Size   int    `json:"size"`
-	Offset int    `json:"offset"`
-	Order  string `json:"order"`
-}
-
-// GetAuditLogs retrieves audit logs from the API with the given options.
-func GetAuditLogs(ctx context.Context, h *api.HTTPAPI, opts AuditLogOpts) (models.PaginationResponse[auditlog.AuditLog], error) {
-	var resp models.PaginationResponse[auditlog.AuditLog]
-
-	_, err := h.GetJSON(ctx, AuditLogV2URI, &resp, api.ReqOptions{
-		Params: map[string]string{
-			"size":   strconv.FormatInt(int64(opts.Size), 10),
-			"offset": strconv.FormatInt(int64(opts.Offset), 10),
-			"order":  opts.Order,
-		},
-	})
-
-	return resp, err
-}
+package auditlog
+
+import (
+	"context"
+	"iter"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/auditlog"
+)
+
+// AuditLogV2URI is the endpoint for the audit logs API.
+const AuditLogV2URI = "/api/v2/audit_logs"
+
+// AuditLogOpts represents options for listing audit logs.
+type AuditLogOpts struct {
+	Size   int    `json:"size"`
+	Offset int    `json:"offset"`
+	Order  string `json:"order"`
+
+	OrderBy []models.OrderBy  `json:"order_by,omitempty"` // Columns to order by, in priority order
+	Filters []models.FilterBy `json:"-"`                  // Additional filters
+}
+
+// GetAuditLogs retrieves audit logs from the API with the given options.
+func GetAuditLogs(ctx context.Context, h *api.HTTPAPI, opts AuditLogOpts) (models.PaginationResponse[auditlog.AuditLog], error) {
+	ctx = api.WithOperation(ctx, "auditlog.GetAuditLogs")
+
+	var resp models.PaginationResponse[auditlog.AuditLog]
+
+	_, err := h.GetJSON(ctx, AuditLogV2URI, &resp, api.ReqOptions{
+		Params: api.NewQuery().
+			Page(opts.Size, opts.Offset, opts.Order).
+			OrderBy(opts.OrderBy...).
+			Filter(opts.Filters...),
+	})
+
+	return resp, err
+}
+
+// All returns an iterator over every audit log, starting at opts.Offset and fetching opts.Size
+// logs per request.
+func All(ctx context.Context, h *api.HTTPAPI, opts AuditLogOpts, pageOpts ...api.PageOption) iter.Seq2[auditlog.AuditLog, error] {
+	fetch := func(ctx context.Context, offset, size int) ([]auditlog.AuditLog, int, error) {
+		page := opts
+		page.Offset, page.Size = offset, size
+
+		resp, err := GetAuditLogs(ctx, h, page)
+		return resp.Data, resp.TotalRows, err
+	}
+
+	return api.Paginate(ctx, opts.Offset, opts.Size, fetch, pageOpts...)
+}
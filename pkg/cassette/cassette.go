@@ -0,0 +1,298 @@
+// Package cassette provides a record/replay http.RoundTripper for testing code built on the SDK
+// without network access.
+//
+// Record real interactions once against a live tenant:
+//
+//	rec, err := cassette.New("testdata/executions.json", cassette.ModeRecord)
+//	client, err := api.New(baseURL, apiKey, api.WithTransport(rec))
+//	// ... exercise the code under test ...
+//	err = rec.Save()
+//
+// and replay them deterministically in CI with cassette.ModeReplay. Bearer tokens, API keys
+// and other secrets are replaced with api.Redacted before interactions are written to disk. Bodies without
+// secrets are recorded byte for byte.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+)
+
+// Mode selects whether the recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette file and never touches the network
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and records them
+	ModeRecord
+	// ModeAuto replays when the cassette file exists and records otherwise
+	ModeAuto
+)
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// cassetteVersion is the version of the file format
+const cassetteVersion = 1
+
+// RecordedRequest is the scrubbed form of a recorded request
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed form of a recorded response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette is the on-disk format of recorded interactions
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Matcher reports whether a recorded request matches an outgoing one
+type Matcher func(req *http.Request, recorded RecordedRequest) bool
+
+// DefaultMatcher matches on method, path and query parameters
+func DefaultMatcher(req *http.Request, recorded RecordedRequest) bool {
+	return req.Method == recorded.Method &&
+		req.URL.Path == recorded.Path &&
+		scrubQuery(req.URL.Query()) == recorded.Query
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+	cassette  Cassette
+	used      []bool
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithTransport sets the transport used in record mode, defaults to http.DefaultTransport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatcher replaces the function used to match requests in replay mode
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// New creates a recorder for the cassette file at path. In replay mode the file must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   DefaultMatcher,
+		cassette:  Cassette{Version: cassetteVersion},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette '%s': %w", path, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette '%s': %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Interactions returns a copy of the recorded or loaded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// replay serves the first unused interaction matching req
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(interaction.Response.Body)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Headers.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// record sends req through the real transport and stores the scrubbed interaction
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   scrubQuery(req.URL.Query()),
+			Headers: scrubHeaders(req.Header),
+			Body:    string(api.RedactJSON(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    scrubHeaders(resp.Header),
+			Body:       string(api.RedactJSON(respBody)),
+		},
+	}
+
+	// The body may shrink or grow when scrubbed, replay computes the length itself
+	interaction.Response.Headers.Del("Content-Length")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette '%s': %w", r.path, err)
+	}
+
+	return nil
+}
+
+// scrubHeaders drops credentials from recorded headers
+func scrubHeaders(headers http.Header) http.Header {
+	out := headers.Clone()
+	for k := range out {
+		switch {
+		case api.IsSensitiveKey(k),
+			strings.EqualFold(k, "Cookie"),
+			strings.EqualFold(k, "Set-Cookie"),
+			strings.EqualFold(k, "X-Api-Key"):
+			out[k] = []string{api.Redacted}
+		}
+	}
+	return out
+}
+
+// scrubQuery returns the canonical encoding of the query with secret params replaced
+func scrubQuery(query url.Values) string {
+	out := url.Values{}
+	for k, v := range query {
+		if api.IsSensitiveKey(k) {
+			v = []string{api.Redacted}
+		}
+		out[k] = v
+	}
+	return out.Encode()
+}
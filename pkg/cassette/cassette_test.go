@@ -0,0 +1,253 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+)
+
+// Bodies served by the recording server. reportBody has no secrets and includes an integer
+// that does not fit in a float64 and keys that are not sorted.
+const (
+	reportBody = `{"id":"e1","total":12345678901234567890,"b":1,"a":[{"z":true,"y":null}]}`
+	assetBody  = `{"id":"a1","apikey":"super-secret","nested":{"token":"also-secret","name":"host"}}`
+)
+
+// newRecordingServer serves the report and asset bodies
+func newRecordingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.URL.Path {
+		case "/api/v2/executions/e1/report":
+			io.WriteString(w, reportBody)
+		case "/api/v2/assets/a1":
+			io.WriteString(w, assetBody)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// get performs a GET through transport and returns the response body
+func get(t *testing.T, transport http.RoundTripper, rawURL string) ([]byte, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer my-api-key")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	srv := newRecordingServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("New(record) error = %v", err)
+	}
+	recorded, err := get(t, rec, srv.URL+"/api/v2/executions/e1/report?size=10&token=abc")
+	if err != nil {
+		t.Fatalf("record error = %v", err)
+	}
+	if string(recorded) != reportBody {
+		t.Errorf("recorded response = %s, want the server body", recorded)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"my-api-key", "session=secret", "abc"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New(replay) error = %v", err)
+	}
+	srv.Close()
+
+	// The token differs from the recorded one, but secret query params are scrubbed before matching
+	replayed, err := get(t, replay, srv.URL+"/api/v2/executions/e1/report?token=other&size=10")
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if !bytes.Equal(replayed, recorded) {
+		t.Errorf("replayed body differs from the recorded one:\n got %s\nwant %s", replayed, recorded)
+	}
+
+	// Each interaction is replayed once
+	if _, err := get(t, replay, srv.URL+"/api/v2/executions/e1/report?size=10&token=abc"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("second replay error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecordScrubsBodies(t *testing.T) {
+	srv := newRecordingServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live, err := get(t, rec, srv.URL+"/api/v2/assets/a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(live) != assetBody {
+		t.Errorf("live response was scrubbed: %s", live)
+	}
+
+	interactions := rec.Interactions()
+	if len(interactions) != 1 {
+		t.Fatalf("%d interactions recorded, want 1", len(interactions))
+	}
+	body := interactions[0].Response.Body
+	if strings.Contains(body, "secret") || !strings.Contains(body, `"name":"host"`) {
+		t.Errorf("recorded body = %s, want secrets redacted and other fields kept", body)
+	}
+	if !strings.Contains(body, `"apikey":"`+api.Redacted+`"`) || !strings.Contains(body, `"token":"`+api.Redacted+`"`) {
+		t.Errorf("recorded body = %s, want secrets replaced with %s", body, api.Redacted)
+	}
+	if got := interactions[0].Request.Headers.Get("Authorization"); got != api.Redacted {
+		t.Errorf("recorded Authorization = %q, want %s", got, api.Redacted)
+	}
+}
+
+func TestRecordReplayWithClient(t *testing.T) {
+	srv := newRecordingServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("Mode() = %v, want ModeRecord without a cassette file", rec.Mode())
+	}
+
+	client, err := api.New(srv.URL, "my-api-key", api.WithTransport(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded map[string]any
+	if _, err := client.GetJSON(context.Background(), "/api/v2/executions/e1/report", &recorded); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Mode() != ModeReplay {
+		t.Fatalf("Mode() = %v, want ModeReplay with a cassette file", replay.Mode())
+	}
+
+	client, err = api.New(srv.URL, "other-key", api.WithTransport(replay))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replayed map[string]any
+	if _, err := client.GetJSON(context.Background(), "/api/v2/executions/e1/report", &replayed); err != nil {
+		t.Fatalf("GetJSON() replay error = %v", err)
+	}
+	if replayed["id"] != "e1" {
+		t.Errorf("replayed report = %v", replayed)
+	}
+}
+
+func TestDefaultMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		recorded RecordedRequest
+		want     bool
+	}{
+		{
+			name:     "same query in another order",
+			method:   http.MethodGet,
+			url:      "https://x/api/v2/executions?size=10&offset=0",
+			recorded: RecordedRequest{Method: http.MethodGet, Path: "/api/v2/executions", Query: "offset=0&size=10"},
+			want:     true,
+		},
+		{
+			name:     "scrubbed secret param",
+			method:   http.MethodGet,
+			url:      "https://x/api/v2/assets?api_key=live-secret",
+			recorded: RecordedRequest{Method: http.MethodGet, Path: "/api/v2/assets", Query: scrubQuery(url.Values{"api_key": {"recorded-secret"}})},
+			want:     true,
+		},
+		{
+			name:     "different param value",
+			method:   http.MethodGet,
+			url:      "https://x/api/v2/executions?size=20",
+			recorded: RecordedRequest{Method: http.MethodGet, Path: "/api/v2/executions", Query: "size=10"},
+			want:     false,
+		},
+		{
+			name:     "different method",
+			method:   http.MethodDelete,
+			url:      "https://x/api/v2/executions/e1",
+			recorded: RecordedRequest{Method: http.MethodGet, Path: "/api/v2/executions/e1"},
+			want:     false,
+		},
+		{
+			name:     "different path",
+			method:   http.MethodGet,
+			url:      "https://x/api/v2/executions/e2",
+			recorded: RecordedRequest{Method: http.MethodGet, Path: "/api/v2/executions/e1"},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := DefaultMatcher(req, tt.recorded); got != tt.want {
+				t.Errorf("DefaultMatcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("New() in replay mode without a cassette file succeeded")
+	}
+}
@@ -0,0 +1,232 @@
+package executions
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// StepChange describes how a step differs between two executions
+type StepChange string
+
+// Kinds of step changes
+const (
+	StepUnchanged StepChange = "unchanged" // Step is in both executions with the same outcome
+	StepChanged   StepChange = "changed"   // Step is in both executions with a different outcome
+	StepAdded     StepChange = "added"     // Step is only in the second execution
+	StepRemoved   StepChange = "removed"   // Step is only in the first execution
+)
+
+// Step fields compared by Compare
+const (
+	FieldSuccess      = "success"
+	FieldDetected     = "detected"
+	FieldLogged       = "logged"
+	FieldSeverity     = "severity"
+	FieldCorrelations = "correlations"
+)
+
+// FieldChange represents a step field whose value differs between two executions
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// StepDiff represents the comparison of a step matched by action and asset across two executions
+type StepDiff struct {
+	ActionID string     `json:"action_id"`
+	AssetID  string     `json:"asset_id"`
+	Hostname string     `json:"hostname,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Change   StepChange `json:"change"`
+
+	// Regression is set when a step detected or logged in the first execution no longer is,
+	// Improvement when a step missed in the first execution is now detected or logged
+	Regression  bool `json:"regression"`
+	Improvement bool `json:"improvement"`
+
+	Fields []FieldChange                   `json:"fields,omitempty"`
+	Before *models.ExecutionStepDetections `json:"before,omitempty"`
+	After  *models.ExecutionStepDetections `json:"after,omitempty"`
+}
+
+// Comparison represents the step by step comparison of two executions
+type Comparison struct {
+	BaseID   string `json:"base_id"`
+	TargetID string `json:"target_id"`
+
+	Regressions  int `json:"regressions"`
+	Improvements int `json:"improvements"`
+	Changed      int `json:"changed"`
+	Added        int `json:"added"`
+	Removed      int `json:"removed"`
+	Unchanged    int `json:"unchanged"`
+
+	Steps []StepDiff `json:"steps"`
+}
+
+// HasRegressions reports whether any step lost its detection or logging
+func (c Comparison) HasRegressions() bool {
+	return c.Regressions > 0
+}
+
+// stepKey identifies a step across executions by its asset, action and position in the step tree.
+// The occurrence distinguishes repeated actions under the same parents on an asset.
+type stepKey struct {
+	assetID, actionID string
+	depth             int
+	parents           string // Action IDs of the enclosing steps, joined by ">"
+	occurrence        int
+}
+
+// keyedSteps flattens the steps of an execution and assigns each its stepKey, preserving order
+func keyedSteps(e models.GetExecutionResponse) ([]stepKey, map[stepKey]models.ExecutionStepDetections) {
+	steps := StepDetections(e)
+	keys := make([]stepKey, 0, len(steps))
+	byKey := make(map[stepKey]models.ExecutionStepDetections, len(steps))
+	seen := map[stepKey]int{}
+
+	for _, step := range steps {
+		actionID := step.ActionID
+		if actionID == "" {
+			actionID = step.Name
+		}
+		base := stepKey{
+			assetID:  step.AssetID,
+			actionID: actionID,
+			depth:    step.Depth,
+			parents:  strings.Join(step.ParentActionIDs, ">"),
+		}
+		key := base
+		key.occurrence = seen[base]
+		seen[base]++
+
+		keys = append(keys, key)
+		byKey[key] = step
+	}
+	return keys, byKey
+}
+
+// Compare matches the steps of execution a to those of execution b by action ID, asset and parent actions,
+// and reports changes in success, detection, logging, severity and correlations. Steps are listed in the
+// order of b, followed by the steps only present in a.
+func Compare(a, b models.GetExecutionResponse) Comparison {
+	c := Comparison{BaseID: a.ID, TargetID: b.ID}
+
+	aKeys, aSteps := keyedSteps(a)
+	bKeys, bSteps := keyedSteps(b)
+
+	for _, key := range bKeys {
+		after := bSteps[key]
+		diff := StepDiff{
+			ActionID: after.ActionID,
+			AssetID:  after.AssetID,
+			Hostname: after.Hostname,
+			Name:     after.Name,
+			After:    &after,
+		}
+
+		before, ok := aSteps[key]
+		if !ok {
+			diff.Change = StepAdded
+			c.Added++
+			c.Steps = append(c.Steps, diff)
+			continue
+		}
+
+		diff.Before = &before
+		diff.Fields = compareSteps(before, after)
+		wasSeen := models.IsTrue(before.Detected) || models.IsTrue(before.Logged)
+		isSeen := models.IsTrue(after.Detected) || models.IsTrue(after.Logged)
+		diff.Regression = (models.IsTrue(before.Detected) && !models.IsTrue(after.Detected)) || (wasSeen && !isSeen)
+		diff.Improvement = !diff.Regression && ((!models.IsTrue(before.Detected) && models.IsTrue(after.Detected)) || (!wasSeen && isSeen))
+
+		if len(diff.Fields) > 0 {
+			diff.Change = StepChanged
+			c.Changed++
+		} else {
+			diff.Change = StepUnchanged
+			c.Unchanged++
+		}
+		if diff.Regression {
+			c.Regressions++
+		}
+		if diff.Improvement {
+			c.Improvements++
+		}
+		c.Steps = append(c.Steps, diff)
+	}
+
+	for _, key := range aKeys {
+		if _, ok := bSteps[key]; ok {
+			continue
+		}
+		before := aSteps[key]
+		c.Removed++
+		c.Steps = append(c.Steps, StepDiff{
+			ActionID: before.ActionID,
+			AssetID:  before.AssetID,
+			Hostname: before.Hostname,
+			Name:     before.Name,
+			Change:   StepRemoved,
+			Before:   &before,
+		})
+	}
+
+	return c
+}
+
+// compareSteps returns the compared fields whose values differ between two steps
+func compareSteps(before, after models.ExecutionStepDetections) []FieldChange {
+	var changes []FieldChange
+	add := func(field, b, a string) {
+		if b != a {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+
+	add(FieldSuccess, formatBool(before.Success), formatBool(after.Success))
+	add(FieldDetected, formatBool(before.Detected), formatBool(after.Detected))
+	add(FieldLogged, formatBool(before.Logged), formatBool(after.Logged))
+	add(FieldSeverity, string(before.Severity), string(after.Severity))
+	add(FieldCorrelations, formatCorrelations(before.Correlations), formatCorrelations(after.Correlations))
+
+	return changes
+}
+
+// formatBool formats an optional bool, with unset values as an empty string
+func formatBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// formatCorrelations summarizes correlations as their count and sorted distinct sources,
+// e.g. "2 (defender, splunk)"
+func formatCorrelations(correlations []models.Correlation) string {
+	if len(correlations) == 0 {
+		return "0"
+	}
+
+	var sources []string
+	for _, c := range correlations {
+		source := c.IntegrationType
+		if source == "" {
+			source = c.Source
+		}
+		if source != "" && !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return strconv.Itoa(len(correlations))
+	}
+
+	slices.Sort(sources)
+	return fmt.Sprintf("%d (%s)", len(correlations), strings.Join(sources, ", "))
+}
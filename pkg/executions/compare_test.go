@@ -0,0 +1,94 @@
+package executions
+
+import (
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// step returns a step of an action with the given detection and action steps
+func step(actionID string, detected bool, children ...models.GetExecutionResponseAssetStep) models.GetExecutionResponseAssetStep {
+	success := true
+	return models.GetExecutionResponseAssetStep{ActionID: actionID, Name: actionID, Success: &success, Detected: &detected, ActionSteps: children}
+}
+
+// execution returns an execution with the steps run on a single asset
+func execution(id string, steps ...models.GetExecutionResponseAssetStep) models.GetExecutionResponse {
+	return models.GetExecutionResponse{
+		ID:     id,
+		Assets: []models.AssetExecutionDetails{{AssetID: "a1", Hostname: "host-1", Steps: steps}},
+	}
+}
+
+func TestCompareNestedSteps(t *testing.T) {
+	// The same action runs at the top level and nested in two composite actions
+	base := execution("base",
+		step("whoami", true),
+		step("discovery", true, step("whoami", true), step("netstat", false)),
+		step("lateral", true, step("whoami", true)),
+	)
+	target := execution("target",
+		step("whoami", true),
+		step("discovery", true, step("whoami", false), step("netstat", true)),
+		step("persistence", true, step("whoami", true)),
+	)
+
+	c := Compare(base, target)
+
+	type result struct {
+		change      StepChange
+		regression  bool
+		improvement bool
+	}
+	got := map[string]result{}
+	for _, d := range c.Steps {
+		var parents []string
+		if d.After != nil {
+			parents = d.After.ParentActionIDs
+		} else {
+			parents = d.Before.ParentActionIDs
+		}
+		key := d.ActionID
+		for i := len(parents) - 1; i >= 0; i-- {
+			key = parents[i] + "/" + key
+		}
+		if _, dup := got[key]; dup {
+			t.Fatalf("step %s listed twice", key)
+		}
+		got[key] = result{d.Change, d.Regression, d.Improvement}
+	}
+
+	want := map[string]result{
+		"whoami":             {change: StepUnchanged},
+		"discovery":          {change: StepUnchanged},
+		"discovery/whoami":   {change: StepChanged, regression: true},
+		"discovery/netstat":  {change: StepChanged, improvement: true},
+		"persistence":        {change: StepAdded},
+		"persistence/whoami": {change: StepAdded},
+		"lateral":            {change: StepRemoved},
+		"lateral/whoami":     {change: StepRemoved},
+	}
+	if len(got) != len(want) {
+		t.Errorf("Compare() returned %d steps, want %d: %+v", len(got), len(want), got)
+	}
+	for key, w := range want {
+		if got[key] != w {
+			t.Errorf("step %s = %+v, want %+v", key, got[key], w)
+		}
+	}
+
+	if c.Regressions != 1 || c.Improvements != 1 || c.Added != 2 || c.Removed != 2 || c.Changed != 2 || c.Unchanged != 2 {
+		t.Errorf("Compare() counts = %+v", c)
+	}
+}
+
+func TestCompareRepeatedSteps(t *testing.T) {
+	// Repeated actions under the same parent are matched in order
+	base := execution("base", step("whoami", true), step("whoami", true))
+	target := execution("target", step("whoami", true), step("whoami", false), step("whoami", false))
+
+	c := Compare(base, target)
+	if c.Unchanged != 1 || c.Regressions != 1 || c.Added != 1 || c.Removed != 0 {
+		t.Errorf("Compare() counts = %+v, want 1 unchanged, 1 regression and 1 added", c)
+	}
+}
@@ -1,130 +1,123 @@
-package executions
-
-import (
-	"context"
-	"fmt"
-	"strconv"
-	"strings"
-	"time"
-
-	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
-	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
-)
-
-// ExecutionsV2URI is the endpoint for the executions API
-const ExecutionsV2URI = "/api/v2/executions"
-
-// ExecutionOpts represents options for listing executions
-type ExecutionOpts struct {
-	Size          int       `json:"size"`
-	Offset        int       `json:"offset"`
-	Order         string    `json:"order"`
-	Name          string    `json:"name,omitempty"`
-	DateBefore    time.Time `json:"date_before,omitempty"`
-	DateAfter     time.Time `json:"date_after,omitempty"`
-	AssetIDs      []string  `json:"asset_id,omitempty"`
-	Hostnames     []string  `json:"hostname,omitempty"`
-	ChainIDs      []string  `json:"chain_id,omitempty"`
-	AttackIDs     []string  `json:"attack_id,omitempty"`
-	ExecutionType []string  `json:"execution_type,omitempty"`
-	Status        string    `json:"status,omitempty"`
-}
-
-// GetExecutions retrieves executions from the API with the given options
-func GetExecutions(ctx context.Context, h *api.HTTPAPI, opts ExecutionOpts) (models.ListWithCountExecutions, error) {
-	var resp models.ListWithCountExecutions
-
-	// Prepare parameters map
-	params := map[string]string{
-		"size":   strconv.FormatInt(int64(opts.Size), 10),
-		"offset": strconv.FormatInt(int64(opts.Offset), 10),
-		"order":  opts.Order,
-	}
-
-	// Add optional filter params if set
-	if opts.Name != "" {
-		params["name"] = opts.Name
-	}
-
-	if !opts.DateBefore.IsZero() {
-		params["date_before"] = opts.DateBefore.Format(time.RFC3339)
-	}
-
-	if !opts.DateAfter.IsZero() {
-		params["date_after"] = opts.DateAfter.Format(time.RFC3339)
-	}
-
-	if opts.Status != "" {
-		params["status"] = opts.Status
-	}
-
-	// Add array parameters
-	if len(opts.AssetIDs) > 0 {
-		params["asset_id"] = strings.Join(opts.AssetIDs, ",")
-	}
-
-	if len(opts.Hostnames) > 0 {
-		params["hostname"] = strings.Join(opts.Hostnames, ",")
-	}
-
-	if len(opts.ChainIDs) > 0 {
-		params["chain_id"] = strings.Join(opts.ChainIDs, ",")
-	}
-
-	if len(opts.AttackIDs) > 0 {
-		params["attack_id"] = strings.Join(opts.AttackIDs, ",")
-	}
-
-	if len(opts.ExecutionType) > 0 {
-		params["execution_type"] = strings.Join(opts.ExecutionType, ",")
-	}
-
-	// Make the API request
-	_, err := h.GetJSON(ctx, ExecutionsV2URI, &resp, api.ReqOptions{
-		Params: params,
-	})
-
-	return resp, err
-}
-
-// GetExecutionReport retrieves a detailed execution report by ID
-func GetExecutionReport(ctx context.Context, h *api.HTTPAPI, executionID string) (models.GetExecutionResponse, error) {
-	var resp models.GetExecutionResponse
-
-	endpoint := fmt.Sprintf("%s/%s/report", ExecutionsV2URI, executionID)
-	_, err := h.GetJSON(ctx, endpoint, &resp)
-
-	return resp, err
-}
-
-func GetExecutionStepReport(ctx context.Context, h *api.HTTPAPI, executionID string) ([]models.ExecutionStepDetections, error) {
-	var resp models.GetExecutionResponse
-	var det []models.ExecutionStepDetections
-
-	endpoint := fmt.Sprintf("%s/%s/report", ExecutionsV2URI, executionID)
-	_, err := h.GetJSON(ctx, endpoint, &resp)
-
-	for _, asset := range resp.Assets {
-		for _, step := range asset.Steps {
-			det = append(det, models.ExecutionStepDetections{
-				GetExecutionResponseAssetStep: step,
-				AssetID:                       asset.AssetID,
-				Hostname:                      asset.Hostname,
-				Platform:                      asset.Platform,
-			})
-		}
-	}
-
-	return det, err
-
-}
-
-// DeleteExecution deletes an execution by ID
-func DeleteExecution(ctx context.Context, h *api.HTTPAPI, executionID string) (models.SuccessIDResponse, error) {
-	var resp models.SuccessIDResponse
-
-	endpoint := fmt.Sprintf("%s/%s", ExecutionsV2URI, executionID)
-	_, err := h.DeleteJSON(ctx, endpoint, nil, &resp)
-
-	return resp, err
-}
+package executions
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// ExecutionsV2URI is the endpoint for the executions API
+const ExecutionsV2URI = "/api/v2/executions"
+
+// ExecutionOpts represents options for listing executions
+type ExecutionOpts struct {
+	Size          int                    `json:"size"`
+	Offset        int                    `json:"offset"`
+	Order         string                 `json:"order"`
+	Name          string                 `json:"name,omitempty"`
+	DateBefore    time.Time              `json:"date_before,omitempty"`
+	DateAfter     time.Time              `json:"date_after,omitempty"`
+	AssetIDs      []string               `json:"asset_id,omitempty"`
+	Hostnames     []string               `json:"hostname,omitempty"`
+	ChainIDs      []string               `json:"chain_id,omitempty"`
+	AttackIDs     []string               `json:"attack_id,omitempty"`
+	ExecutionType []models.ExecutionType `json:"execution_type,omitempty"`
+	Status        models.ExecutionStatus `json:"status,omitempty"`
+
+	OrderBy []models.OrderBy  `json:"order_by,omitempty"` // Columns to order by, in priority order
+	Filters []models.FilterBy `json:"-"`                  // Additional filters, overriding the ones above
+}
+
+// GetExecutions retrieves executions from the API with the given options
+func GetExecutions(ctx context.Context, h *api.HTTPAPI, opts ExecutionOpts) (models.ListWithCountExecutions, error) {
+	ctx = api.WithOperation(ctx, "executions.GetExecutions")
+
+	var resp models.ListWithCountExecutions
+
+	params := api.NewQuery().
+		Page(opts.Size, opts.Offset, opts.Order).
+		Set("name", opts.Name).
+		SetTime("date_before", opts.DateBefore).
+		SetTime("date_after", opts.DateAfter).
+		Set("status", string(opts.Status)).
+		SetList("asset_id", opts.AssetIDs).
+		SetList("hostname", opts.Hostnames).
+		SetList("chain_id", opts.ChainIDs).
+		SetList("attack_id", opts.AttackIDs).
+		SetList("execution_type", models.EnumStrings(opts.ExecutionType)).
+		OrderBy(opts.OrderBy...).
+		Filter(opts.Filters...)
+
+	// Make the API request
+	_, err := h.GetJSON(ctx, ExecutionsV2URI, &resp, api.ReqOptions{
+		Params: params,
+	})
+
+	return resp, err
+}
+
+// GetExecutionReport retrieves a detailed execution report by ID
+func GetExecutionReport(ctx context.Context, h *api.HTTPAPI, executionID string) (models.GetExecutionResponse, error) {
+	ctx = api.WithOperation(ctx, "executions.GetExecutionReport")
+
+	var resp models.GetExecutionResponse
+
+	endpoint := fmt.Sprintf("%s/%s/report", ExecutionsV2URI, executionID)
+	_, err := h.GetJSON(ctx, endpoint, &resp)
+
+	return resp, err
+}
+
+// GetExecutionStepReport retrieves the steps of an execution report, flattened by StepDetections
+func GetExecutionStepReport(ctx context.Context, h *api.HTTPAPI, executionID string) ([]models.ExecutionStepDetections, error) {
+	ctx = api.WithOperation(ctx, "executions.GetExecutionStepReport")
+
+	var resp models.GetExecutionResponse
+
+	endpoint := fmt.Sprintf("%s/%s/report", ExecutionsV2URI, executionID)
+	_, err := h.GetJSON(ctx, endpoint, &resp)
+
+	return StepDetections(resp), err
+}
+
+// StepDetections flattens the steps of every asset in an execution report, including the action steps
+// nested in composite actions, annotated with their asset and depth. Steps are listed in the order of WalkSteps.
+func StepDetections(e models.GetExecutionResponse) []models.ExecutionStepDetections {
+	var det []models.ExecutionStepDetections
+
+	for v := range Steps(e) {
+		det = append(det, v.Detections())
+	}
+
+	return det
+}
+
+// DeleteExecution deletes an execution by ID
+func DeleteExecution(ctx context.Context, h *api.HTTPAPI, executionID string) (models.SuccessIDResponse, error) {
+	ctx = api.WithOperation(ctx, "executions.DeleteExecution")
+
+	var resp models.SuccessIDResponse
+
+	endpoint := fmt.Sprintf("%s/%s", ExecutionsV2URI, executionID)
+	_, err := h.DeleteJSON(ctx, endpoint, nil, &resp)
+
+	return resp, err
+}
+
+// All returns an iterator over every execution matching opts, starting at opts.Offset and
+// fetching opts.Size executions per request
+func All(ctx context.Context, h *api.HTTPAPI, opts ExecutionOpts, pageOpts ...api.PageOption) iter.Seq2[models.GetExecutionResponse, error] {
+	fetch := func(ctx context.Context, offset, size int) ([]models.GetExecutionResponse, int, error) {
+		page := opts
+		page.Offset, page.Size = offset, size
+
+		resp, err := GetExecutions(ctx, h, page)
+		return resp.Data, resp.Count, err
+	}
+
+	return api.Paginate(ctx, opts.Offset, opts.Size, fetch, pageOpts...)
+}
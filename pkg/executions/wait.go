@@ -0,0 +1,195 @@
+package executions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// Default polling settings used by Wait
+const (
+	DefaultPollInterval    = 2 * time.Second
+	DefaultMaxPollInterval = 30 * time.Second
+	DefaultMaxUnknownPolls = 10
+)
+
+var (
+	// ErrExecutionFailed is returned by Wait when the execution or one of its assets reports a failure
+	ErrExecutionFailed = errors.New("execution failed")
+	// ErrUnknownStatus is returned by Wait when the execution keeps reporting an unrecognized status
+	ErrUnknownStatus = errors.New("execution status not recognized")
+)
+
+// IsFinished reports whether an execution has reached a terminal state
+func IsFinished(e models.GetExecutionResponse) bool {
+	return e.Status.IsTerminal() || e.StatusState.IsTerminal()
+}
+
+// isRunning reports whether an execution reports a running status
+func isRunning(e models.GetExecutionResponse) bool {
+	return e.Status.IsRunning() || e.StatusState.IsRunning()
+}
+
+// failMessage formats a fail_error value, returning "" when it is not set or empty
+func failMessage(v any) string {
+	if msg, ok := v.(string); ok {
+		return msg
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	switch string(data) {
+	case "null", "{}", "[]", "false":
+		return ""
+	}
+	return string(data)
+}
+
+// failure returns the failure reported by an execution or, with withAssets, by one of its assets, or nil
+func failure(e models.GetExecutionResponse, withAssets bool) error {
+	if msg := failMessage(e.FailError); msg != "" {
+		return fmt.Errorf("%w: %s", ErrExecutionFailed, msg)
+	}
+	if !withAssets {
+		return nil
+	}
+	for _, a := range e.Assets {
+		if msg := failMessage(a.FailError); msg != "" {
+			name := a.Hostname
+			if name == "" {
+				name = a.AssetID
+			}
+			return fmt.Errorf("%w: asset %s: %s", ErrExecutionFailed, name, msg)
+		}
+	}
+	return nil
+}
+
+// WaitOpts represents options for waiting on an execution
+type WaitOpts struct {
+	PollInterval    time.Duration // Initial delay between polls, defaults to DefaultPollInterval
+	MaxPollInterval time.Duration // Upper bound for the delay between polls, defaults to DefaultMaxPollInterval
+	Multiplier      float64       // Growth factor of the delay while nothing changes, defaults to 1.5
+	Timeout         time.Duration // Give up after this long, 0 waits until ctx is done
+
+	// MaxUnknownPolls gives up after this many consecutive polls reporting a status that is neither
+	// inprogress nor finished, defaults to DefaultMaxUnknownPolls. Negative values never give up.
+	MaxUnknownPolls int
+
+	// OnProgress is called with the latest report whenever its status, progress or steps change,
+	// including once for the first report
+	OnProgress func(models.GetExecutionResponse)
+}
+
+// progressSnapshot holds the fields compared between polls to detect progress
+type progressSnapshot struct {
+	status, statusState                                    models.ExecutionStatus
+	progress                                               float64
+	totalFinished, totalSuccess, totalDetected, stepsCount int
+}
+
+// snapshot captures the progress of an execution report
+func snapshot(e models.GetExecutionResponse) progressSnapshot {
+	s := progressSnapshot{
+		status:        e.Status,
+		statusState:   e.StatusState,
+		progress:      e.Progress,
+		totalFinished: e.TotalFinished,
+		totalSuccess:  e.TotalSuccess,
+		totalDetected: e.TotalDetected,
+	}
+	for _, a := range e.Assets {
+		s.stepsCount += len(a.Steps)
+	}
+	return s
+}
+
+// Wait polls the report of an execution until it reaches a terminal state and returns the final report.
+// The delay between polls grows by opts.Multiplier while nothing changes and resets on progress.
+// The API only reports inprogress and finished, so an execution is done once either status is finished.
+// A fail_error on the execution also ends the wait. When the execution or one of its assets reports a
+// fail_error, the final report is returned with an error wrapping ErrExecutionFailed.
+// If the status is neither inprogress nor finished for opts.MaxUnknownPolls consecutive polls, the last
+// report is returned with ErrUnknownStatus. If ctx is done or the timeout expires first, the last report
+// seen is returned with the context error.
+func Wait(ctx context.Context, h *api.HTTPAPI, executionID string, opts WaitOpts) (models.GetExecutionResponse, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = DefaultMaxPollInterval
+	}
+	if opts.MaxPollInterval < opts.PollInterval {
+		opts.MaxPollInterval = opts.PollInterval
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 1.5
+	}
+	if opts.MaxUnknownPolls == 0 {
+		opts.MaxUnknownPolls = DefaultMaxUnknownPolls
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var last models.GetExecutionResponse
+	var lastSnapshot progressSnapshot
+	interval := opts.PollInterval
+	unknownPolls := 0
+
+	for first := true; ; first = false {
+		resp, err := GetExecutionReport(ctx, h, executionID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, err
+		}
+		last = resp
+
+		current := snapshot(resp)
+		if first || current != lastSnapshot {
+			lastSnapshot = current
+			interval = opts.PollInterval
+			if opts.OnProgress != nil {
+				opts.OnProgress(resp)
+			}
+		} else {
+			interval = min(time.Duration(float64(interval)*opts.Multiplier), opts.MaxPollInterval)
+		}
+
+		if IsFinished(resp) {
+			return resp, failure(resp, true)
+		}
+		if err := failure(resp, false); err != nil {
+			return resp, err
+		}
+
+		if isRunning(resp) {
+			unknownPolls = 0
+		} else if unknownPolls++; opts.MaxUnknownPolls > 0 && unknownPolls >= opts.MaxUnknownPolls {
+			status := resp.Status
+			if status == "" {
+				status = resp.StatusState
+			}
+			return resp, fmt.Errorf("%w: %q after %d polls", ErrUnknownStatus, status, unknownPolls)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
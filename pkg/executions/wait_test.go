@@ -0,0 +1,229 @@
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/fakeserver"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// fastWait returns wait options polling every millisecond
+func fastWait() WaitOpts {
+	return WaitOpts{PollInterval: time.Millisecond, MaxPollInterval: 5 * time.Millisecond, Timeout: 10 * time.Second}
+}
+
+// newWaitServer returns a fake server holding execution e1 with the given status and a client of it.
+// Before the n-th poll of the report, starting at 1, update is applied to e1.
+func newWaitServer(t *testing.T, status models.ExecutionStatus, update func(n int, e *models.GetExecutionResponse)) (*fakeserver.Server, *api.HTTPAPI) {
+	t.Helper()
+
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	srv.AddExecution(models.GetExecutionResponse{
+		ID:     "e1",
+		Status: status,
+		Assets: []models.AssetExecutionDetails{{AssetID: "a1", Hostname: "host-1"}},
+	})
+
+	polls := 0
+	client := srv.Client(api.WithMiddleware(func(next api.Handler) api.Handler {
+		return func(ctx context.Context, req *api.Request) (*api.Response, error) {
+			polls++
+			if update != nil {
+				srv.UpdateExecution("e1", func(e *models.GetExecutionResponse) { update(polls, e) })
+			}
+			return next(ctx, req)
+		}
+	}))
+	return srv, client
+}
+
+// reportPolls counts the report requests received by srv
+func reportPolls(srv *fakeserver.Server) int {
+	n := 0
+	for _, c := range srv.Calls() {
+		if c.Path == "/api/v2/executions/e1/report" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestWait(t *testing.T) {
+	srv, client := newWaitServer(t, models.StatusInProgress, func(n int, e *models.GetExecutionResponse) {
+		switch n {
+		case 3:
+			e.Progress = 50
+		case 6:
+			e.Status, e.Progress = models.StatusFinished, 100
+		}
+	})
+
+	var progress []float64
+	opts := fastWait()
+	opts.OnProgress = func(e models.GetExecutionResponse) { progress = append(progress, e.Progress) }
+
+	got, err := Wait(context.Background(), client, "e1", opts)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if got.Status != models.StatusFinished || got.Progress != 100 {
+		t.Errorf("Wait() = %s at %v%%, want the finished report", got.Status, got.Progress)
+	}
+	if n := reportPolls(srv); n != 6 {
+		t.Errorf("%d polls, want 6", n)
+	}
+
+	// Called for the first report and on every change only
+	if len(progress) != 3 || progress[0] != 0 || progress[1] != 50 || progress[2] != 100 {
+		t.Errorf("OnProgress calls = %v, want 0, 50 and 100", progress)
+	}
+}
+
+func TestWaitFinishedByStatusState(t *testing.T) {
+	_, client := newWaitServer(t, "", func(n int, e *models.GetExecutionResponse) {
+		e.StatusState = models.StatusFinished
+	})
+
+	if got, err := Wait(context.Background(), client, "e1", fastWait()); err != nil || !IsFinished(got) {
+		t.Errorf("Wait() = %+v, %v, want the report finished by its status_state", got, err)
+	}
+}
+
+func TestWaitExecutionFailure(t *testing.T) {
+	srv, client := newWaitServer(t, models.StatusInProgress, func(n int, e *models.GetExecutionResponse) {
+		if n == 2 {
+			e.FailError = "no stager available"
+		}
+	})
+
+	// A fail_error ends the wait although the execution is still in progress
+	got, err := Wait(context.Background(), client, "e1", fastWait())
+	if !errors.Is(err, ErrExecutionFailed) || err.Error() != "execution failed: no stager available" {
+		t.Errorf("Wait() error = %v, want ErrExecutionFailed", err)
+	}
+	if got.FailError != "no stager available" {
+		t.Errorf("Wait() = %+v, want the failed report", got)
+	}
+	if n := reportPolls(srv); n != 2 {
+		t.Errorf("%d polls, want 2", n)
+	}
+}
+
+func TestWaitAssetFailure(t *testing.T) {
+	_, client := newWaitServer(t, models.StatusInProgress, func(n int, e *models.GetExecutionResponse) {
+		switch n {
+		case 1:
+			// An asset failure alone does not stop the other assets
+			e.Assets[0].FailError = map[string]any{"message": "agent disconnected"}
+		case 3:
+			e.Status = models.StatusFinished
+		}
+	})
+
+	got, err := Wait(context.Background(), client, "e1", fastWait())
+	if !errors.Is(err, ErrExecutionFailed) || err.Error() != `execution failed: asset host-1: {"message":"agent disconnected"}` {
+		t.Errorf("Wait() error = %v, want the asset failure", err)
+	}
+	if got.Status != models.StatusFinished {
+		t.Errorf("Wait() status = %s, want the finished report", got.Status)
+	}
+}
+
+func TestWaitUnknownStatus(t *testing.T) {
+	srv, client := newWaitServer(t, "queued", nil)
+
+	opts := fastWait()
+	opts.MaxUnknownPolls = 3
+	got, err := Wait(context.Background(), client, "e1", opts)
+	if !errors.Is(err, ErrUnknownStatus) || got.ID != "e1" {
+		t.Errorf("Wait() = %+v, %v, want ErrUnknownStatus", got, err)
+	}
+	if n := reportPolls(srv); n != 3 {
+		t.Errorf("%d polls, want 3", n)
+	}
+}
+
+func TestWaitUnknownStatusRecovers(t *testing.T) {
+	srv, client := newWaitServer(t, "", func(n int, e *models.GetExecutionResponse) {
+		switch n {
+		case 3:
+			e.Status = models.StatusInProgress
+		case 4:
+			e.Status = ""
+		case 6:
+			e.Status = models.StatusFinished
+		}
+	})
+
+	// The count of unknown polls restarts once the execution reports a running status
+	opts := fastWait()
+	opts.MaxUnknownPolls = 3
+	if _, err := Wait(context.Background(), client, "e1", opts); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+	if n := reportPolls(srv); n != 6 {
+		t.Errorf("%d polls, want 6", n)
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          models.ExecutionStatus
+		maxUnknownPolls int
+	}{
+		{name: "in progress", status: models.StatusInProgress},
+		{name: "unknown status without a poll limit", status: "queued", maxUnknownPolls: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newWaitServer(t, tt.status, nil)
+
+			opts := fastWait()
+			opts.Timeout = 50 * time.Millisecond
+			opts.MaxUnknownPolls = tt.maxUnknownPolls
+			got, err := Wait(context.Background(), client, "e1", opts)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Wait() error = %v, want context.DeadlineExceeded", err)
+			}
+			if got.ID != "e1" {
+				t.Errorf("Wait() = %+v, want the last report", got)
+			}
+		})
+	}
+}
+
+func TestWaitNotFound(t *testing.T) {
+	_, client := newWaitServer(t, models.StatusInProgress, nil)
+
+	if _, err := Wait(context.Background(), client, "missing", fastWait()); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("Wait() error = %v, want api.ErrNotFound", err)
+	}
+}
+
+func TestFailMessage(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{"", ""},
+		{"boom", "boom"},
+		{map[string]any{}, ""},
+		{[]any{}, ""},
+		{false, ""},
+		{map[string]any{"code": 3}, `{"code":3}`},
+		{[]any{"a", "b"}, `["a","b"]`},
+	}
+	for _, tt := range tests {
+		if got := failMessage(tt.value); got != tt.want {
+			t.Errorf("failMessage(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package executions
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// SkipChildren can be returned by a WalkStepsFunc to skip the nested action steps of the visited step
+var SkipChildren = errors.New("skip children")
+
+// errStopWalk stops a walk early when the consumer of Steps stops iterating
+var errStopWalk = errors.New("stop walk")
+
+// StepVisit is a step visited by WalkSteps or Steps, with its position in the step tree.
+// Step, Asset and Parents point into the walked execution and must not be modified.
+type StepVisit struct {
+	Step    *models.GetExecutionResponseAssetStep
+	Asset   *models.AssetExecutionDetails
+	Depth   int                                     // 0 for the steps of an asset, 1 for their action steps, and so on
+	Index   int                                     // Position of the step among its siblings
+	Parents []*models.GetExecutionResponseAssetStep // Enclosing steps, from the top-level step to the direct parent
+}
+
+// Parent returns the step whose action steps include the visited step, or nil for a top-level step
+func (v StepVisit) Parent() *models.GetExecutionResponseAssetStep {
+	if len(v.Parents) == 0 {
+		return nil
+	}
+	return v.Parents[len(v.Parents)-1]
+}
+
+// Detections returns the visited step annotated with its asset and position in the step tree
+func (v StepVisit) Detections() models.ExecutionStepDetections {
+	det := models.ExecutionStepDetections{
+		GetExecutionResponseAssetStep: *v.Step,
+		AssetID:                       v.Asset.AssetID,
+		Hostname:                      v.Asset.Hostname,
+		Platform:                      v.Asset.Platform,
+		Depth:                         v.Depth,
+	}
+	for _, p := range v.Parents {
+		det.ParentActionIDs = append(det.ParentActionIDs, p.ActionID)
+	}
+	return det
+}
+
+// WalkStepsFunc is called by WalkSteps for each step. Returning SkipChildren skips the action steps of
+// the visited step, and returning any other error stops the walk.
+type WalkStepsFunc func(v StepVisit) error
+
+// WalkSteps calls fn for every step of every asset in an execution report, including the action steps
+// nested in composite actions, in depth-first order with each step visited before its action steps.
+// It returns the error returned by fn, other than SkipChildren.
+func WalkSteps(e models.GetExecutionResponse, fn WalkStepsFunc) error {
+	for i := range e.Assets {
+		if err := walkSteps(&e.Assets[i], e.Assets[i].Steps, nil, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkSteps visits steps and their action steps, with parents being the steps enclosing them
+func walkSteps(asset *models.AssetExecutionDetails, steps []models.GetExecutionResponseAssetStep, parents []*models.GetExecutionResponseAssetStep, fn WalkStepsFunc) error {
+	for i := range steps {
+		step := &steps[i]
+		err := fn(StepVisit{Step: step, Asset: asset, Depth: len(parents), Index: i, Parents: slices.Clone(parents)})
+		if errors.Is(err, SkipChildren) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := walkSteps(asset, step.ActionSteps, append(parents, step), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Steps returns an iterator over every step of every asset in an execution report, in the order
+// of WalkSteps
+func Steps(e models.GetExecutionResponse) iter.Seq[StepVisit] {
+	return func(yield func(StepVisit) bool) {
+		WalkSteps(e, func(v StepVisit) error {
+			if !yield(v) {
+				return errStopWalk
+			}
+			return nil
+		})
+	}
+}
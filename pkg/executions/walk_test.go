@@ -0,0 +1,159 @@
+package executions
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// walkExecution returns an execution with a composite step nesting two levels of action steps on a1,
+// and a single step on a2
+func walkExecution() models.GetExecutionResponse {
+	e := execution("e1",
+		step("a", false,
+			step("a.1", true,
+				step("a.1.1", false),
+			),
+			step("a.2", false),
+		),
+		step("b", true),
+	)
+	e.Assets = append(e.Assets, models.AssetExecutionDetails{AssetID: "a2", Steps: []models.GetExecutionResponseAssetStep{step("c", false)}})
+	return e
+}
+
+// visitPath describes a visit as its asset, parent chain and step, e.g. a1:a>a.1
+func visitPath(v StepVisit) string {
+	var names []string
+	for _, p := range v.Parents {
+		names = append(names, p.ActionID)
+	}
+	return v.Asset.AssetID + ":" + strings.Join(append(names, v.Step.ActionID), ">")
+}
+
+func TestWalkSteps(t *testing.T) {
+	var paths []string
+	var depths, indexes []int
+	err := WalkSteps(walkExecution(), func(v StepVisit) error {
+		paths = append(paths, visitPath(v))
+		depths = append(depths, v.Depth)
+		indexes = append(indexes, v.Index)
+		if v.Depth != len(v.Parents) {
+			t.Errorf("%s: depth %d with %d parents", visitPath(v), v.Depth, len(v.Parents))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkSteps() error = %v", err)
+	}
+
+	// Depth-first, each step before its action steps
+	wantPaths := []string{"a1:a", "a1:a>a.1", "a1:a>a.1>a.1.1", "a1:a>a.2", "a1:b", "a2:c"}
+	if !slices.Equal(paths, wantPaths) {
+		t.Errorf("visited %v, want %v", paths, wantPaths)
+	}
+	if want := []int{0, 1, 2, 1, 0, 0}; !slices.Equal(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+	if want := []int{0, 0, 0, 1, 1, 0}; !slices.Equal(indexes, want) {
+		t.Errorf("indexes = %v, want %v", indexes, want)
+	}
+}
+
+func TestWalkStepsParents(t *testing.T) {
+	e := walkExecution()
+
+	var visits []StepVisit
+	WalkSteps(e, func(v StepVisit) error {
+		visits = append(visits, v)
+		return nil
+	})
+
+	// Visits point into the walked execution
+	top := visits[0]
+	if top.Parent() != nil || top.Step != &e.Assets[0].Steps[0] || top.Asset != &e.Assets[0] {
+		t.Errorf("top-level visit = %+v, want the first step of a1 without a parent", top)
+	}
+
+	// The parents of a visit are not changed by the visits of later siblings
+	deepest, sibling := visits[2], visits[3]
+	if deepest.Parent().ActionID != "a.1" || deepest.Parents[0].ActionID != "a" {
+		t.Errorf("parents of a.1.1 = %s, want a > a.1", visitPath(deepest))
+	}
+	if sibling.Parent().ActionID != "a" || len(sibling.Parents) != 1 {
+		t.Errorf("parents of a.2 = %s, want a", visitPath(sibling))
+	}
+
+	det := deepest.Detections()
+	if det.ActionID != "a.1.1" || det.AssetID != "a1" || det.Hostname != "host-1" || det.Depth != 2 || !slices.Equal(det.ParentActionIDs, []string{"a", "a.1"}) {
+		t.Errorf("Detections() = %+v", det)
+	}
+}
+
+func TestWalkStepsSkipChildren(t *testing.T) {
+	var paths []string
+	err := WalkSteps(walkExecution(), func(v StepVisit) error {
+		paths = append(paths, visitPath(v))
+		if v.Step.ActionID == "a.1" {
+			return SkipChildren
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkSteps() error = %v, want SkipChildren to be swallowed", err)
+	}
+
+	// Only the action steps of a.1 are pruned, its siblings are still visited
+	want := []string{"a1:a", "a1:a>a.1", "a1:a>a.2", "a1:b", "a2:c"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("visited %v, want %v", paths, want)
+	}
+}
+
+func TestWalkStepsError(t *testing.T) {
+	errStop := errors.New("stop")
+
+	var paths []string
+	err := WalkSteps(walkExecution(), func(v StepVisit) error {
+		paths = append(paths, visitPath(v))
+		if v.Step.ActionID == "a.1.1" {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("WalkSteps() error = %v, want the error of fn", err)
+	}
+	if want := []string{"a1:a", "a1:a>a.1", "a1:a>a.1>a.1.1"}; !slices.Equal(paths, want) {
+		t.Errorf("visited %v, want the walk to stop at a.1.1", paths)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	var paths []string
+	for v := range Steps(walkExecution()) {
+		paths = append(paths, visitPath(v))
+	}
+	if want := []string{"a1:a", "a1:a>a.1", "a1:a>a.1>a.1.1", "a1:a>a.2", "a1:b", "a2:c"}; !slices.Equal(paths, want) {
+		t.Errorf("Steps() = %v, want the order of WalkSteps", paths)
+	}
+
+	// Breaking out of the loop stops the walk, including from nested steps
+	paths = nil
+	for v := range Steps(walkExecution()) {
+		paths = append(paths, visitPath(v))
+		if v.Depth == 2 {
+			break
+		}
+	}
+	if want := []string{"a1:a", "a1:a>a.1", "a1:a>a.1>a.1.1"}; !slices.Equal(paths, want) {
+		t.Errorf("Steps() with break = %v, want %v", paths, want)
+	}
+
+	for range Steps(models.GetExecutionResponse{}) {
+		t.Error("Steps() of an execution without assets yielded a step")
+	}
+}
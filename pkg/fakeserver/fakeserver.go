@@ -0,0 +1,380 @@
+// Package fakeserver provides an in-memory fake of the FourCore v2 API built on httptest,
+// for testing code built on the SDK without a live tenant.
+//
+//	srv := fakeserver.New()
+//	defer srv.Close()
+//
+//	srv.AddAsset(asset.Asset{ID: "a1", Connected: true})
+//	srv.InjectRateLimit(asset.AssetsV2URI, 1, time.Second)
+//
+//	client := srv.Client()
+//	assets, err := asset.GetAssets(ctx, client)
+//
+// State is seeded with the Add methods and mutated by the API calls the SDK makes, e.g.
+// running a chain creates an execution that can then be listed and fetched.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/asset"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/auditlog"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/mitre"
+)
+
+// DefaultAPIKey is the API key accepted by the server unless WithAPIKey is used
+const DefaultAPIKey = "fake-api-key"
+
+// Call is a request received by the server
+type Call struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// fault is an injected failure for requests under a path prefix
+type fault struct {
+	prefix     string
+	remaining  int // 0 means forever
+	status     int
+	apiErr     api.APIError
+	retryAfter time.Duration
+}
+
+// Server is an in-memory fake of the FourCore v2 API
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	apiKey      string
+	latency     time.Duration
+	faults      []*fault
+	calls       []Call
+	nextID      int
+	requests    int
+	assets      []asset.Asset
+	emailAssets []asset.EmailAsset
+	executions  []models.GetExecutionResponse
+	attacks     map[string][]asset.AssetAttack
+	packs       map[string][]models.PackRun
+	agentLogs   []agentlog.AgentLog
+	auditLogs   []auditlog.AuditLog
+	mitre       []mitre.MitreTacticTechniqueWithActionAndStagers
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAPIKey sets the bearer token the server accepts, an empty key disables authentication
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithLatency delays every response by d
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// New starts a fake server. Close it when done.
+func New(opts ...Option) *Server {
+	s := &Server{
+		apiKey:  DefaultAPIKey,
+		attacks: make(map[string][]asset.AssetAttack),
+		packs:   make(map[string][]models.PackRun),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an API client for the server authenticated with its API key.
+// Retries are disabled so injected failures surface directly; pass options to override.
+func (s *Server) Client(opts ...api.Option) *api.HTTPAPI {
+	opts = append([]api.Option{api.WithRetryPolicy(api.NoRetryPolicy())}, opts...)
+
+	client, err := api.New(s.URL, s.apiKey, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("fakeserver: failed to create client: %v", err))
+	}
+	return client
+}
+
+// SetLatency delays every subsequent response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// InjectRateLimit makes the next times requests under pathPrefix fail with 429 and the given
+// Retry-After. times <= 0 rate limits every request until ClearFaults is called.
+func (s *Server) InjectRateLimit(pathPrefix string, times int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{
+		prefix:     pathPrefix,
+		remaining:  times,
+		status:     http.StatusTooManyRequests,
+		apiErr:     api.APIError{Detail: "Rate limit exceeded"},
+		retryAfter: retryAfter,
+	})
+}
+
+// InjectError makes the next times requests under pathPrefix fail with apiErr as the payload.
+// The status is taken from apiErr.StatusCode, Status or Code, in that order, defaulting to 500.
+// times <= 0 fails every request until ClearFaults is called.
+func (s *Server) InjectError(pathPrefix string, times int, apiErr api.APIError) {
+	status := http.StatusInternalServerError
+	for _, code := range []int{apiErr.StatusCode, apiErr.Status, apiErr.Code} {
+		if code >= http.StatusBadRequest {
+			status = code
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{prefix: pathPrefix, remaining: times, status: status, apiErr: apiErr})
+}
+
+// ClearFaults removes all injected rate limits and errors
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Calls returns the requests received so far
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.calls)
+}
+
+// AddAsset adds an endpoint asset
+func (s *Server) AddAsset(a asset.Asset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == "" {
+		a.ID = s.newID("asset")
+	}
+	s.assets = append(s.assets, a)
+}
+
+// AddEmailAsset adds an email asset
+func (s *Server) AddEmailAsset(a asset.EmailAsset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == "" {
+		a.ID = s.newID("email")
+	}
+	s.emailAssets = append(s.emailAssets, a)
+}
+
+// AddExecution adds an execution report
+func (s *Server) AddExecution(e models.GetExecutionResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.ID == "" {
+		e.ID = s.newID("exec")
+	}
+	s.executions = append(s.executions, e)
+}
+
+// UpdateExecution applies fn to the stored execution with the given ID, e.g. to move it to a
+// terminal status. It reports whether the execution exists.
+func (s *Server) UpdateExecution(id string, fn func(*models.GetExecutionResponse)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.executions {
+		if s.executions[i].ID == id {
+			fn(&s.executions[i])
+			return true
+		}
+	}
+	return false
+}
+
+// AddAssetAttack adds an entry to the attacks listed for an asset
+func (s *Server) AddAssetAttack(assetID string, attack asset.AssetAttack) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attack.ID == "" {
+		attack.ID = s.newID("attack")
+	}
+	s.attacks[assetID] = append(s.attacks[assetID], attack)
+}
+
+// AddAssetPack adds an assessment run to the packs listed for an asset
+func (s *Server) AddAssetPack(assetID string, pack models.PackRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pack.ID == "" {
+		pack.ID = s.newID("pack")
+	}
+	s.packs[assetID] = append(s.packs[assetID], pack)
+}
+
+// AddAgentLog adds an agent log entry
+func (s *Server) AddAgentLog(l agentlog.AgentLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l.ID == "" {
+		l.ID = s.newID("agentlog")
+	}
+	s.agentLogs = append(s.agentLogs, l)
+}
+
+// AddAuditLog adds an audit log entry
+func (s *Server) AddAuditLog(l auditlog.AuditLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l.ID == "" {
+		l.ID = s.newID("auditlog")
+	}
+	s.auditLogs = append(s.auditLogs, l)
+}
+
+// AddMitreTechnique adds a MITRE ATT&CK coverage entry
+func (s *Server) AddMitreTechnique(t mitre.MitreTacticTechniqueWithActionAndStagers) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mitre = append(s.mitre, t)
+}
+
+// Executions returns the stored executions, including those created by run endpoints
+func (s *Server) Executions() []models.GetExecutionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.executions)
+}
+
+// newID returns a unique ID with the given prefix, s.mu must be held
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%04d", prefix, s.nextID)
+}
+
+// serveHTTP records the call, checks authentication, applies latency and faults, then routes it.
+// Unauthenticated requests fail before latency and never consume a fault.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := readBody(r)
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+	s.requests++
+	requestID := fmt.Sprintf("req-%04d", s.requests)
+	latency := s.latency
+	apiKey := s.apiKey
+	s.mu.Unlock()
+
+	w.Header().Set("X-Request-Id", requestID)
+
+	if apiKey != "" && r.Header.Get("Authorization") != "Bearer "+apiKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API Key")
+		return
+	}
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	f := s.takeFault(r.URL.Path)
+	s.mu.Unlock()
+
+	if f != nil {
+		if f.status == http.StatusTooManyRequests && f.retryAfter > 0 {
+			seconds := strconv.FormatInt(int64(f.retryAfter.Round(time.Second)/time.Second), 10)
+			w.Header().Set("Retry-After", seconds)
+			w.Header().Set("x-ratelimit-retry-after", seconds)
+		}
+		apiErr := f.apiErr
+		if apiErr.Code == 0 {
+			apiErr.Code = f.status
+		}
+		if apiErr.Status == 0 {
+			apiErr.Status = f.status
+		}
+		if apiErr.Title == "" {
+			apiErr.Title = http.StatusText(f.status)
+		}
+		writeJSON(w, f.status, apiErr)
+		return
+	}
+
+	s.route(w, r, body)
+}
+
+// takeFault returns the first fault matching path and consumes one use of it, s.mu must be held
+func (s *Server) takeFault(path string) *fault {
+	for i, f := range s.faults {
+		if !strings.HasPrefix(path, f.prefix) {
+			continue
+		}
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error payload in the APIError shape
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, api.APIError{
+		Code:   status,
+		Status: status,
+		Title:  http.StatusText(status),
+		Detail: detail,
+	})
+}
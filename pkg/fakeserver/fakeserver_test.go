@@ -0,0 +1,332 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/asset"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	assetmodels "github.com/fourcorelabs/attack-sdk-go/pkg/models/asset"
+)
+
+// do sends a request to srv with its API key and returns the status code and Retry-After header
+func do(t *testing.T, srv *Server, method, path string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+DefaultAPIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, resp.Header.Get("Retry-After")
+}
+
+func TestRouting(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.AddAsset(assetmodels.Asset{ID: "a1"})
+	srv.AddExecution(models.GetExecutionResponse{ID: "e1"})
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/api/v2/assets", http.StatusOK},
+		{http.MethodGet, "/api/v2/assets/a1", http.StatusOK},
+		{http.MethodGet, "/api/v2/assets/missing", http.StatusNotFound},
+		{http.MethodPut, "/api/v2/assets/a1/disable", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v2/assets/a1/attacks", http.StatusOK},
+		{http.MethodGet, "/api/v2/assets/a1/packs", http.StatusOK},
+		{http.MethodGet, "/api/v2/assets/email", http.StatusOK},
+		{http.MethodGet, "/api/v2/executions", http.StatusOK},
+		{http.MethodGet, "/api/v2/executions/e1/report", http.StatusOK},
+		{http.MethodGet, "/api/v2/executions/e2/report", http.StatusNotFound},
+		{http.MethodGet, "/api/v2/agent_logs", http.StatusOK},
+		{http.MethodGet, "/api/v2/audit_logs", http.StatusOK},
+		{http.MethodGet, "/api/v2/mitre/all", http.StatusOK},
+		{http.MethodGet, "/api/v2/actions/run", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v2/unknown", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/assets", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		if got, _ := do(t, srv, tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	if len(srv.Calls()) != len(tests) {
+		t.Errorf("%d calls recorded, want %d", len(srv.Calls()), len(tests))
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv := New(WithAPIKey("other"))
+	defer srv.Close()
+
+	if got, _ := do(t, srv, http.MethodGet, "/api/v2/assets"); got != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", got)
+	}
+}
+
+func TestUnauthorizedBeforeLatencyAndFaults(t *testing.T) {
+	srv := New(WithAPIKey("other"))
+	defer srv.Close()
+
+	srv.SetLatency(time.Minute)
+	srv.InjectError(asset.AssetsV2URI, 1, api.APIError{Status: http.StatusServiceUnavailable})
+
+	// Rejected at once, without waiting for the latency or consuming the fault
+	start := time.Now()
+	if got, _ := do(t, srv, http.MethodGet, "/api/v2/assets"); got != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", got)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("unauthenticated request took %v", elapsed)
+	}
+
+	srv.SetLatency(0)
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v2/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer other")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("authenticated status = %d, want the injected 503", resp.StatusCode)
+	}
+}
+
+func TestInjectRateLimitConsumed(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.InjectRateLimit(asset.AssetsV2URI, 2, 3*time.Second)
+
+	for i := range 2 {
+		status, retryAfter := do(t, srv, http.MethodGet, "/api/v2/assets")
+		if status != http.StatusTooManyRequests || retryAfter != "3" {
+			t.Errorf("request %d = %d with Retry-After %q, want 429 with 3", i, status, retryAfter)
+		}
+	}
+
+	// Other paths are not affected, and the fault is used up after two requests
+	if status, _ := do(t, srv, http.MethodGet, "/api/v2/executions"); status != http.StatusOK {
+		t.Errorf("other path status = %d, want 200", status)
+	}
+	if status, _ := do(t, srv, http.MethodGet, "/api/v2/assets"); status != http.StatusOK {
+		t.Errorf("status after the injected rate limits = %d, want 200", status)
+	}
+}
+
+func TestInjectErrorWithClient(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.InjectError(executions.ExecutionsV2URI, 1, api.APIError{StatusCode: http.StatusNotFound, Detail: "gone"})
+	client := srv.Client()
+
+	_, err := executions.GetExecutions(context.Background(), client, executions.ExecutionOpts{})
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, api.ErrNotFound) || apiErr.Detail != "gone" {
+		t.Fatalf("GetExecutions() error = %v, want the injected not found error", err)
+	}
+
+	if _, err := executions.GetExecutions(context.Background(), client, executions.ExecutionOpts{}); err != nil {
+		t.Errorf("GetExecutions() after the injected error = %v", err)
+	}
+}
+
+func TestInjectErrorUntilCleared(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.InjectError("/api/v2/", 0, api.APIError{Status: http.StatusServiceUnavailable})
+
+	for range 3 {
+		if status, _ := do(t, srv, http.MethodGet, "/api/v2/assets"); status != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want 503", status)
+		}
+	}
+
+	srv.ClearFaults()
+	if status, _ := do(t, srv, http.MethodGet, "/api/v2/assets"); status != http.StatusOK {
+		t.Errorf("status after ClearFaults = %d, want 200", status)
+	}
+}
+
+func TestTakeFault(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.InjectError("/api/v2/assets", 1, api.APIError{Status: http.StatusBadGateway})
+	srv.InjectRateLimit("/api/v2/", 0, 0)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	// The first matching fault is used and removed once it has no uses left
+	if f := srv.takeFault("/api/v2/assets"); f == nil || f.status != http.StatusBadGateway {
+		t.Fatalf("takeFault() = %+v, want the injected error", f)
+	}
+	if len(srv.faults) != 1 {
+		t.Fatalf("%d faults left, want 1", len(srv.faults))
+	}
+
+	// Faults injected for every request are never removed
+	for range 3 {
+		if f := srv.takeFault("/api/v2/assets"); f == nil || f.status != http.StatusTooManyRequests {
+			t.Fatalf("takeFault() = %+v, want the injected rate limit", f)
+		}
+	}
+	if len(srv.faults) != 1 {
+		t.Errorf("%d faults left, want 1", len(srv.faults))
+	}
+
+	if f := srv.takeFault("/api/v1/assets"); f != nil {
+		t.Errorf("takeFault() for an unmatched path = %+v", f)
+	}
+}
+
+// executionIDs returns the IDs of executions
+func executionIDs(list []models.GetExecutionResponse) []string {
+	ids := make([]string, 0, len(list))
+	for _, e := range list {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestListExecutionsOrderByAndFilters(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	for _, e := range []models.GetExecutionResponse{
+		{ID: "e1", AttackName: "b", Score: 2, Status: models.StatusFinished},
+		{ID: "e2", AttackName: "a", Score: 2, Status: models.StatusFinished},
+		{ID: "e3", AttackName: "c", Score: 10, Status: models.StatusInProgress},
+		{ID: "e4", AttackName: "d", Score: 1, OrgID: 7, Status: models.StatusFinished},
+	} {
+		srv.AddExecution(e)
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts executions.ExecutionOpts
+		want []string
+	}{
+		{
+			name: "newest first by default",
+			want: []string{"e4", "e3", "e2", "e1"},
+		},
+		{
+			name: "order by number",
+			opts: executions.ExecutionOpts{OrderBy: []models.OrderBy{{Name: "score"}}},
+			want: []string{"e3", "e1", "e2", "e4"},
+		},
+		{
+			name: "order by several columns",
+			opts: executions.ExecutionOpts{OrderBy: []models.OrderBy{{Name: "score"}, {Name: "attack_name", Asc: true}}},
+			want: []string{"e3", "e2", "e1", "e4"},
+		},
+		{
+			name: "generic filter",
+			opts: executions.ExecutionOpts{Filters: []models.FilterBy{{Name: "org_id", Value: []string{"7"}}}},
+			want: []string{"e4"},
+		},
+		{
+			name: "generic filter with several values and paging",
+			opts: executions.ExecutionOpts{
+				Size:    1,
+				Offset:  1,
+				OrderBy: []models.OrderBy{{Name: "attack_name", Asc: true}},
+				Filters: []models.FilterBy{{Name: "attack_name", Value: []string{"a", "b", "c"}}},
+			},
+			want: []string{"e1"},
+		},
+		{
+			name: "typed and generic filters",
+			opts: executions.ExecutionOpts{
+				Status:  models.StatusFinished,
+				Filters: []models.FilterBy{{Name: "score", Value: []string{"2"}}},
+			},
+			want: []string{"e2", "e1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := executions.GetExecutions(ctx, client, tt.opts)
+			if err != nil {
+				t.Fatalf("GetExecutions() error = %v", err)
+			}
+			if got := executionIDs(resp.Data); !slices.Equal(got, tt.want) {
+				t.Errorf("GetExecutions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRejectsUnknownFields(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.AddExecution(models.GetExecutionResponse{ID: "e1"})
+	client := srv.Client()
+	ctx := context.Background()
+
+	for _, opts := range []executions.ExecutionOpts{
+		{Filters: []models.FilterBy{{Name: "colour", Value: []string{"red"}}}},
+		{OrderBy: []models.OrderBy{{Name: "colour"}}},
+	} {
+		_, err := executions.GetExecutions(ctx, client, opts)
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("GetExecutions(%+v) error = %v, want a 400", opts, err)
+		}
+	}
+
+	if status, _ := do(t, srv, http.MethodGet, "/api/v2/executions?order_by=score:sideways"); status != http.StatusBadRequest {
+		t.Errorf("invalid order_by direction status = %d, want 400", status)
+	}
+}
+
+func TestListAssetPacksFilters(t *testing.T) {
+	srv := New()
+	defer srv.Close()
+
+	srv.AddAsset(assetmodels.Asset{ID: "a1"})
+	srv.AddAssetPack("a1", models.PackRun{ID: "p1", Name: "Ransomware", Total: 5})
+	srv.AddAssetPack("a1", models.PackRun{ID: "p2", Name: "Discovery", Total: 3})
+
+	packs, err := asset.GetAssetPacks(context.Background(), srv.Client(), "a1", asset.GetAssetExecutionsOpts{
+		Name:    "ransom",
+		Filters: []models.FilterBy{{Name: "total", Value: []string{"5"}}},
+	})
+	if err != nil {
+		t.Fatalf("GetAssetPacks() error = %v", err)
+	}
+	if len(packs) != 1 || packs[0].ID != "p1" {
+		t.Errorf("GetAssetPacks() = %+v, want p1", packs)
+	}
+}
@@ -0,0 +1,791 @@
+package fakeserver
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/api"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/agentlog"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/asset"
+)
+
+// apiPrefix is the path prefix of every v2 endpoint
+const apiPrefix = "/api/v2/"
+
+// readBody reads the request body
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	return io.ReadAll(r.Body)
+}
+
+// route dispatches a request to the handler of its endpoint
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "Route not found")
+		return
+	}
+	seg := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	switch {
+	case seg[0] == "assets" && len(seg) > 1 && seg[1] == "email":
+		s.routeEmailAssets(w, r, seg[2:], body)
+	case seg[0] == "assets":
+		s.routeAssets(w, r, seg[1:], body)
+	case seg[0] == "executions":
+		s.routeExecutions(w, r, seg[1:])
+	case seg[0] == "agent_logs" && len(seg) == 1:
+		if allow(w, r, http.MethodGet) {
+			s.listAgentLogs(w, r.URL.Query())
+		}
+	case seg[0] == "audit_logs" && len(seg) == 1:
+		if allow(w, r, http.MethodGet) {
+			s.listAuditLogs(w, r.URL.Query())
+		}
+	case seg[0] == "mitre" && len(seg) == 2:
+		if allow(w, r, http.MethodGet) {
+			s.getMitre(w, seg[1])
+		}
+	case seg[0] == "actions" && len(seg) == 2 && seg[1] == "run":
+		if allow(w, r, http.MethodPost) {
+			s.runAction(w, body)
+		}
+	case seg[0] == "chains" && len(seg) == 3 && seg[2] == "run":
+		if allow(w, r, http.MethodPost) {
+			s.runChain(w, seg[1], models.ExecutionTypeEndpointSecurity, body)
+		}
+	case seg[0] == "email" && len(seg) == 4 && seg[1] == "chain" && seg[3] == "run":
+		if allow(w, r, http.MethodPost) {
+			s.runChain(w, seg[2], models.ExecutionTypeEmailInfiltration, body)
+		}
+	case seg[0] == "waf" && len(seg) == 4 && seg[1] == "chain" && seg[3] == "run":
+		if allow(w, r, http.MethodPost) {
+			s.runChain(w, seg[2], models.ExecutionTypeWAF, body)
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Route not found")
+	}
+}
+
+// allow reports whether the request uses method, writing a 405 otherwise
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return false
+	}
+	return true
+}
+
+// --- Assets ---
+
+// routeAssets handles /api/v2/assets and /api/v2/assets/{id}/...
+func (s *Server) routeAssets(w http.ResponseWriter, r *http.Request, seg []string, body []byte) {
+	if len(seg) == 0 || seg[0] == "" {
+		if allow(w, r, http.MethodGet) {
+			s.mu.Lock()
+			assets := slices.Clone(s.assets)
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, assets)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.IndexFunc(s.assets, func(a asset.Asset) bool { return a.ID == seg[0] })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "Asset not found")
+		return
+	}
+	a := &s.assets[idx]
+
+	action := ""
+	if len(seg) > 1 {
+		action = seg[1]
+	}
+
+	switch action {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, a)
+		case http.MethodDelete:
+			s.assets = slices.Delete(s.assets, idx, idx+1)
+			writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: seg[0]})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case "disable", "enable":
+		if allow(w, r, http.MethodPost) {
+			a.Disabled = action == "disable"
+			writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: a.ID})
+		}
+	case "tags":
+		if allow(w, r, http.MethodPost) {
+			var tags asset.AssetTags
+			if err := json.Unmarshal(body, &tags); err != nil {
+				writeError(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			a.Tags = tags.Tags
+			writeJSON(w, http.StatusOK, asset.AssetSetTagsResponse{Success: true, Tags: tags})
+		}
+	case "analytics":
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, s.assetAnalytics(a.ID))
+		}
+	case "attacks":
+		if allow(w, r, http.MethodGet) {
+			s.listAssetAttacks(w, a.ID, r.URL.Query())
+		}
+	case "executions":
+		if allow(w, r, http.MethodGet) {
+			s.listAssetExecutions(w, a.ID, r.URL.Query())
+		}
+	case "packs":
+		if allow(w, r, http.MethodGet) {
+			s.listAssetPacks(w, a.ID, r.URL.Query())
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Route not found")
+	}
+}
+
+// listAssetAttacks writes the attacks run on an asset matching the query filters, s.mu must be held
+func (s *Server) listAssetAttacks(w http.ResponseWriter, assetID string, q url.Values) {
+	dateAfter, dateBefore, ok := dateRange(w, q)
+	if !ok {
+		return
+	}
+
+	var matched []asset.AssetAttack
+	for _, a := range s.attacks[assetID] {
+		if name := q.Get("name"); name != "" && !strings.Contains(strings.ToLower(a.Name), strings.ToLower(name)) {
+			continue
+		}
+		if status := q.Get("status"); status != "" && string(a.Status) != status {
+			continue
+		}
+		if !inTimeRange(a.CreatedAt, dateAfter, dateBefore) {
+			continue
+		}
+		matched = append(matched, a)
+	}
+
+	matched, ok = selectItems(w, matched, q, assetListParams...)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, models.ListWithCount[asset.AssetAttack]{Count: len(matched), Data: paginate(matched, q)})
+}
+
+// listAssetPacks writes the assessment runs of an asset matching the query filters, s.mu must be held
+func (s *Server) listAssetPacks(w http.ResponseWriter, assetID string, q url.Values) {
+	dateAfter, dateBefore, ok := dateRange(w, q)
+	if !ok {
+		return
+	}
+
+	var matched []models.PackRun
+	for _, p := range s.packs[assetID] {
+		if name := q.Get("name"); name != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(name)) {
+			continue
+		}
+		if status := q.Get("status"); status != "" && string(p.Status) != status && string(p.StatusState) != status {
+			continue
+		}
+		var createdAt *time.Time
+		if p.CreatedAt != nil {
+			if t, err := time.Parse(time.RFC3339, *p.CreatedAt); err == nil {
+				createdAt = &t
+			}
+		}
+		if !inTimeRange(createdAt, dateAfter, dateBefore) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+
+	matched, ok = selectItems(w, matched, q, assetListParams...)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(matched, q))
+}
+
+// listAssetExecutions writes the executions run on an asset matching the query filters, s.mu must be held
+func (s *Server) listAssetExecutions(w http.ResponseWriter, assetID string, q url.Values) {
+	q = maps.Clone(q)
+	q.Set("asset_id", assetID)
+	s.listExecutions(w, q)
+}
+
+// assetAnalytics summarises the executions that ran on an asset, s.mu must be held
+func (s *Server) assetAnalytics(assetID string) asset.AssetAnalytics {
+	var analytics asset.AssetAnalytics
+	for _, e := range s.executions {
+		for _, a := range e.Assets {
+			if a.AssetID != assetID {
+				continue
+			}
+			analytics.Total += a.TotalAttacks
+			analytics.Success += a.TotalSuccess
+			analytics.Detected += a.TotalDetected
+		}
+	}
+	return analytics
+}
+
+// routeEmailAssets handles /api/v2/assets/email and /api/v2/assets/email/{id}/...
+func (s *Server) routeEmailAssets(w http.ResponseWriter, r *http.Request, seg []string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(seg) == 0 || seg[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.emailAssets)
+		case http.MethodPost:
+			var req asset.CreateEmailAssetRequest
+			if err := json.Unmarshal(body, &req); err != nil || req.Email == "" {
+				writeError(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			now := time.Now().UTC()
+			a := asset.EmailAsset{ID: s.newID("email"), Email: req.Email, Tags: req.Tags, CreatedAt: &now, UpdatedAt: &now}
+			s.emailAssets = append(s.emailAssets, a)
+			writeJSON(w, http.StatusOK, a)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	idx := slices.IndexFunc(s.emailAssets, func(a asset.EmailAsset) bool { return a.ID == seg[0] })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "Email asset not found")
+		return
+	}
+	a := &s.emailAssets[idx]
+
+	switch strings.Join(seg[1:], "/") {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, a)
+		case http.MethodPut:
+			var req asset.CreateEmailAssetRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				writeError(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			now := time.Now().UTC()
+			a.Email, a.Tags, a.UpdatedAt = req.Email, req.Tags, &now
+			writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: a.ID})
+		case http.MethodDelete:
+			s.emailAssets = slices.Delete(s.emailAssets, idx, idx+1)
+			writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: seg[0]})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case "verify":
+		if allow(w, r, http.MethodPost) {
+			a.Verified = true
+			writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: a.ID})
+		}
+	case "analytics":
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, asset.EmailAssetAnalytics{})
+		}
+	case "gmail/confirmation":
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, asset.GmailConfCode{EmailAssetID: a.ID})
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Route not found")
+	}
+}
+
+// --- Executions ---
+
+// routeExecutions handles /api/v2/executions and /api/v2/executions/{id}/...
+func (s *Server) routeExecutions(w http.ResponseWriter, r *http.Request, seg []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(seg) == 0 || seg[0] == "" {
+		if allow(w, r, http.MethodGet) {
+			s.listExecutions(w, r.URL.Query())
+		}
+		return
+	}
+
+	idx := slices.IndexFunc(s.executions, func(e models.GetExecutionResponse) bool { return e.ID == seg[0] })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "Execution not found")
+		return
+	}
+
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		s.executions = slices.Delete(s.executions, idx, idx+1)
+		writeJSON(w, http.StatusOK, models.SuccessIDResponse{Success: true, ID: seg[0]})
+	case len(seg) == 2 && seg[1] == "report":
+		if allow(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, s.executions[idx])
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Route not found")
+	}
+}
+
+// listExecutions writes the executions matching the query filters, s.mu must be held
+func (s *Server) listExecutions(w http.ResponseWriter, q url.Values) {
+	dateAfter, dateBefore, ok := dateRange(w, q)
+	if !ok {
+		return
+	}
+
+	var matched []models.GetExecutionResponse
+	for _, e := range s.executions {
+		if name := q.Get("name"); name != "" && !strings.Contains(strings.ToLower(e.AttackName), strings.ToLower(name)) {
+			continue
+		}
+		if status := q.Get("status"); status != "" && string(e.Status) != status && string(e.StatusState) != status {
+			continue
+		}
+		if !inTimeRange(e.CreatedAt, dateAfter, dateBefore) ||
+			!matchesAny(q, "execution_type", func(v string) bool { return string(e.ExecutionType) == v }) ||
+			!matchesAny(q, "chain_id", func(v string) bool { return e.ChainID == v }) ||
+			!matchesAny(q, "attack_id", func(v string) bool { return strconv.Itoa(e.AttackID) == v }) ||
+			!matchesAny(q, "asset_id", func(v string) bool { return executionHasAsset(e, v) }) ||
+			!matchesAny(q, "hostname", func(v string) bool { return executionHasHostname(e, v) }) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	matched, ok = selectItems(w, matched, q, executionListParams...)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, models.ListWithCountExecutions{Count: len(matched), Data: paginate(matched, q)})
+}
+
+// executionHasAsset reports whether an execution ran on the asset
+func executionHasAsset(e models.GetExecutionResponse, assetID string) bool {
+	for _, a := range e.Assets {
+		if a.AssetID == assetID {
+			return true
+		}
+	}
+	for _, h := range e.Hostname {
+		if h.AssetID == assetID {
+			return true
+		}
+	}
+	return false
+}
+
+// executionHasHostname reports whether an execution ran on a host with the given name
+func executionHasHostname(e models.GetExecutionResponse, hostname string) bool {
+	for _, a := range e.Assets {
+		if a.Hostname == hostname {
+			return true
+		}
+	}
+	for _, h := range e.Hostname {
+		if h.Name == hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// runAction creates an execution for /api/v2/actions/run
+func (s *Server) runAction(w http.ResponseWriter, body []byte) {
+	var run models.AttackRunActionsStagers
+	if err := json.Unmarshal(body, &run); err != nil || len(run.Actions) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.newExecution(run.AttackRun, models.ExecutionTypeEndpointSecurity)
+	e.ActionIDs = run.Actions
+	s.executions = append(s.executions, e)
+	writeJSON(w, http.StatusOK, e)
+}
+
+// runChain creates an execution for the endpoint, email and WAF chain run endpoints
+func (s *Server) runChain(w http.ResponseWriter, chainID string, executionType models.ExecutionType, body []byte) {
+	var run models.AttackRun
+	if err := json.Unmarshal(body, &run); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.newExecution(run, executionType)
+	e.ChainID = chainID
+	s.executions = append(s.executions, e)
+
+	if executionType != models.ExecutionTypeEmailInfiltration {
+		writeJSON(w, http.StatusOK, e)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.AttackExecution{
+		ID:            e.ID,
+		ChainID:       chainID,
+		ExecutionType: executionType,
+		EmailAssetIDs: run.EmailAssets,
+		Status:        e.Status,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	})
+}
+
+// newExecution returns an in-progress execution for run, s.mu must be held
+func (s *Server) newExecution(run models.AttackRun, executionType models.ExecutionType) models.GetExecutionResponse {
+	now := time.Now().UTC()
+	e := models.GetExecutionResponse{
+		ID:            s.newID("exec"),
+		ExecutionType: executionType,
+		Status:        models.StatusInProgress,
+		StatusState:   models.StatusInProgress,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if run.RunElevated != nil {
+		e.RunElevated = *run.RunElevated
+	}
+
+	assetIDs := slices.Concat(run.Assets, run.EmailAssets, run.WafAssets)
+	for _, id := range assetIDs {
+		details := models.AssetExecutionDetails{AssetID: id, Status: models.StatusInProgress}
+		if idx := slices.IndexFunc(s.assets, func(a asset.Asset) bool { return a.ID == id }); idx >= 0 && s.assets[idx].SystemInfo != nil {
+			info := s.assets[idx].SystemInfo
+			details.Hostname, details.IPAddr, details.Platform, details.Arch = info.Hostname, info.IPAddr, info.OS, info.Arch
+		}
+		e.Assets = append(e.Assets, details)
+		e.Hostname = append(e.Hostname, models.Hostname{AssetID: id, Name: details.Hostname, IPAddr: details.IPAddr, OS: details.Platform})
+	}
+	e.AssetCount = len(assetIDs)
+
+	return e
+}
+
+// --- Logs ---
+
+// listAgentLogs writes the agent logs matching the query filters
+func (s *Server) listAgentLogs(w http.ResponseWriter, q url.Values) {
+	dateAfter, dateBefore, ok := dateRange(w, q)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []agentlog.AgentLog
+	for _, l := range s.agentLogs {
+		if action := q.Get("action"); action != "" && l.Action != action {
+			continue
+		}
+		if query := q.Get("q"); query != "" && !strings.Contains(strings.ToLower(l.Message), strings.ToLower(query)) {
+			continue
+		}
+		if !inTimeRange(l.CreatedAt, dateAfter, dateBefore) ||
+			!matchesAny(q, "asset_id", func(v string) bool { return l.AssetID == v }) {
+			continue
+		}
+		matched = append(matched, l)
+	}
+
+	matched, ok = selectItems(w, matched, q, agentLogListParams...)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, paginationResponse(matched, q))
+}
+
+// listAuditLogs writes a page of audit logs
+func (s *Server) listAuditLogs(w http.ResponseWriter, q url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logs, ok := selectItems(w, s.auditLogs, q)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, paginationResponse(logs, q))
+}
+
+// --- MITRE ---
+
+// getMitre writes the full coverage for /api/v2/mitre/all, or a single technique
+func (s *Server) getMitre(w http.ResponseWriter, techniqueID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if techniqueID == "all" {
+		writeJSON(w, http.StatusOK, s.mitre)
+		return
+	}
+
+	for _, t := range s.mitre {
+		if t.TechniqueID == techniqueID || t.AbsoluteID == techniqueID {
+			writeJSON(w, http.StatusOK, t)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Technique not found")
+}
+
+// --- Query helpers ---
+
+// Params handled by the list endpoints themselves, every other param is a generic filter
+var (
+	pageParams          = []string{"size", "offset", "order", api.OrderByParam}
+	assetListParams     = []string{"name", "status", "date_after", "date_before"}
+	executionListParams = []string{"name", "status", "date_after", "date_before", "execution_type", "chain_id", "attack_id", "asset_id", "hostname"}
+	agentLogListParams  = []string{"action", "q", "date_after", "date_before", "asset_id"}
+)
+
+// selectItems applies the generic filters and the order_by param to items, writing a 400 if
+// they name a field items do not have. Params other than the page params and the typed ones
+// handled by the endpoint filter on the JSON field of the same name, matching any of their
+// comma separated values. order_by sorts by each column in turn, keeping ties in stored order.
+func selectItems[T any](w http.ResponseWriter, items []T, q url.Values, typed ...string) ([]T, bool) {
+	fields := map[string]bool{}
+	jsonFields(reflect.TypeFor[T](), fields)
+
+	var filters []string
+	for param := range q {
+		if slices.Contains(pageParams, param) || slices.Contains(typed, param) {
+			continue
+		}
+		if !fields[param] {
+			writeError(w, http.StatusBadRequest, "Unknown filter "+param)
+			return nil, false
+		}
+		filters = append(filters, param)
+	}
+
+	var order []models.OrderBy
+	if v := q.Get(api.OrderByParam); v != "" {
+		for _, column := range strings.Split(v, ",") {
+			o, err := api.ParseOrderBy(column)
+			if err != nil || !fields[o.Name] {
+				writeError(w, http.StatusBadRequest, "Invalid order_by column "+column)
+				return nil, false
+			}
+			order = append(order, o)
+		}
+	}
+
+	if len(filters) == 0 && len(order) == 0 {
+		return items, true
+	}
+
+	type row struct {
+		item   T
+		fields map[string]any
+	}
+	rows := make([]row, 0, len(items))
+	for _, item := range items {
+		m, err := toFields(item)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to encode item")
+			return nil, false
+		}
+		matched := true
+		for _, param := range filters {
+			matched = matched && matchesAny(q, param, func(v string) bool { return fmt.Sprint(m[param]) == v })
+		}
+		if matched {
+			rows = append(rows, row{item: item, fields: m})
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b row) int {
+		for _, o := range order {
+			c := compareFields(a.fields[o.Name], b.fields[o.Name])
+			if !o.Asc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	selected := make([]T, 0, len(rows))
+	for _, r := range rows {
+		selected = append(selected, r.item)
+	}
+	return selected, true
+}
+
+// jsonFields adds the top-level JSON field names of t to fields, including those of embedded structs
+func jsonFields(t reflect.Type, fields map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case f.Anonymous && name == "":
+			jsonFields(f.Type, fields)
+		case !f.IsExported():
+		case name == "":
+			fields[f.Name] = true
+		default:
+			fields[name] = true
+		}
+	}
+}
+
+// toFields returns the top-level JSON fields of item, keeping numbers as json.Number
+func toFields(item any) (map[string]any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]any
+	err = dec.Decode(&m)
+	return m, err
+}
+
+// compareFields orders two decoded JSON values, with missing and null values first
+func compareFields(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch a := a.(type) {
+	case json.Number:
+		if b, ok := b.(json.Number); ok {
+			af, _ := a.Float64()
+			bf, _ := b.Float64()
+			return cmp.Compare(af, bf)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case b:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// paginate returns the page of items selected by the size, offset and order params.
+// Items are stored oldest first; any order other than asc returns the newest first, unless
+// order_by is set, in which case items are kept in the order selectItems sorted them in.
+// A size of 0 returns every remaining item.
+func paginate[T any](items []T, q url.Values) []T {
+	items = slices.Clone(items)
+	if q.Get(api.OrderByParam) == "" && !strings.EqualFold(q.Get("order"), "asc") {
+		slices.Reverse(items)
+	}
+
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if offset < 0 || offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+
+	if size, _ := strconv.Atoi(q.Get("size")); size > 0 && size < len(items) {
+		items = items[:size]
+	}
+	return items
+}
+
+// paginationResponse wraps a page of items in a models.PaginationResponse
+func paginationResponse[T any](items []T, q url.Values) models.PaginationResponse[T] {
+	offset, _ := strconv.ParseUint(q.Get("offset"), 10, 64)
+	size, _ := strconv.ParseUint(q.Get("size"), 10, 64)
+
+	return models.PaginationResponse[T]{
+		Pagination: models.Pagination{Offset: offset, Size: size},
+		TotalRows:  len(items),
+		Data:       paginate(items, q),
+	}
+}
+
+// matchesAny reports whether a comma separated filter param is unset or any of its values matches
+func matchesAny(q url.Values, param string, match func(string) bool) bool {
+	v := q.Get(param)
+	if v == "" {
+		return true
+	}
+	return slices.ContainsFunc(strings.Split(v, ","), match)
+}
+
+// dateRange parses the date_after and date_before params, writing a 400 if they are invalid
+func dateRange(w http.ResponseWriter, q url.Values) (after, before time.Time, ok bool) {
+	for param, t := range map[string]*time.Time{"date_after": &after, "date_before": &before} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid "+param)
+			return after, before, false
+		}
+		*t = parsed
+	}
+	return after, before, true
+}
+
+// inTimeRange reports whether t lies within the optional bounds
+func inTimeRange(t *time.Time, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (after.IsZero() || t.After(after)) && (before.IsZero() || t.Before(before))
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ExecutionStatus represents the lifecycle status of an execution, pack run, asset or step
+type ExecutionStatus string
+
+// Known execution statuses, the ones the API documents
+const (
+	StatusUnknown    ExecutionStatus = "unknown"
+	StatusInProgress ExecutionStatus = "inprogress"
+	StatusFinished   ExecutionStatus = "finished"
+)
+
+var executionStatuses = []ExecutionStatus{StatusUnknown, StatusInProgress, StatusFinished}
+
+// ParseExecutionStatus parses a known execution status, case-insensitively
+func ParseExecutionStatus(s string) (ExecutionStatus, error) {
+	return parseEnum("status", ExecutionStatus(normalizeEnum(s)), executionStatuses)
+}
+
+// Valid reports whether s is a known execution status
+func (s ExecutionStatus) Valid() bool {
+	return slices.Contains(executionStatuses, s)
+}
+
+// IsTerminal reports whether the status is final, after which the execution no longer changes
+func (s ExecutionStatus) IsTerminal() bool {
+	return s == StatusFinished
+}
+
+// IsRunning reports whether the status is in progress
+func (s ExecutionStatus) IsRunning() bool {
+	return s == StatusInProgress
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next.
+// Executions move from in progress to finished and never leave finished. Unknown statuses allow any transition.
+func (s ExecutionStatus) CanTransitionTo(next ExecutionStatus) bool {
+	if s == next || !s.Valid() || !next.Valid() || s == StatusUnknown {
+		return true
+	}
+	return s == StatusInProgress && next.IsTerminal()
+}
+
+// UnmarshalText accepts any value, matching known statuses case-insensitively and keeping
+// other values as sent so they round-trip
+func (s *ExecutionStatus) UnmarshalText(text []byte) error {
+	*s = unmarshalEnum(text, executionStatuses)
+	return nil
+}
+
+// ExecutionType represents the kind of attack an execution ran
+type ExecutionType string
+
+// Known execution types
+const (
+	ExecutionTypeEndpointSecurity  ExecutionType = "endpoint_security"
+	ExecutionTypeDataExfil         ExecutionType = "data_exfil"
+	ExecutionTypeFirewall          ExecutionType = "firewall"
+	ExecutionTypeEmailInfiltration ExecutionType = "email_infiltration"
+	ExecutionTypeWAF               ExecutionType = "waf"
+)
+
+var executionTypes = []ExecutionType{
+	ExecutionTypeEndpointSecurity, ExecutionTypeDataExfil, ExecutionTypeFirewall,
+	ExecutionTypeEmailInfiltration, ExecutionTypeWAF,
+}
+
+// ParseExecutionType parses a known execution type, case-insensitively
+func ParseExecutionType(s string) (ExecutionType, error) {
+	return parseEnum("execution type", ExecutionType(normalizeEnum(s)), executionTypes)
+}
+
+// Valid reports whether t is a known execution type
+func (t ExecutionType) Valid() bool {
+	return slices.Contains(executionTypes, t)
+}
+
+// UnmarshalText accepts any value, matching known types case-insensitively and keeping
+// other values as sent
+func (t *ExecutionType) UnmarshalText(text []byte) error {
+	*t = unmarshalEnum(text, executionTypes)
+	return nil
+}
+
+// StagerMode represents how a stager delivers an attack to an asset
+type StagerMode string
+
+// Known stager modes
+const (
+	StagerModeExe        StagerMode = "exe"
+	StagerModeDLL        StagerMode = "dll"
+	StagerModePowerShell StagerMode = "powershell"
+	StagerModeShellcode  StagerMode = "shellcode"
+)
+
+var stagerModes = []StagerMode{StagerModeExe, StagerModeDLL, StagerModePowerShell, StagerModeShellcode}
+
+// ParseStagerMode parses a known stager mode, case-insensitively
+func ParseStagerMode(s string) (StagerMode, error) {
+	return parseEnum("stager mode", StagerMode(normalizeEnum(s)), stagerModes)
+}
+
+// Valid reports whether m is a known stager mode
+func (m StagerMode) Valid() bool {
+	return slices.Contains(stagerModes, m)
+}
+
+// UnmarshalText accepts any value, matching known modes case-insensitively and keeping
+// other values as sent
+func (m *StagerMode) UnmarshalText(text []byte) error {
+	*m = unmarshalEnum(text, stagerModes)
+	return nil
+}
+
+// Severity represents the severity of an attack step
+type Severity string
+
+// Known severities, from least to most severe
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+var severities = []Severity{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// ParseSeverity parses a known severity, case-insensitively
+func ParseSeverity(s string) (Severity, error) {
+	return parseEnum("severity", Severity(normalizeEnum(s)), severities)
+}
+
+// Valid reports whether s is a known severity
+func (s Severity) Valid() bool {
+	return slices.Contains(severities, s)
+}
+
+// Rank orders severities from 1 (info) to 5 (critical), with 0 for unknown values
+func (s Severity) Rank() int {
+	return slices.Index(severities, s) + 1
+}
+
+// UnmarshalText accepts any value, matching known severities case-insensitively and keeping
+// other values as sent
+func (s *Severity) UnmarshalText(text []byte) error {
+	*s = unmarshalEnum(text, severities)
+	return nil
+}
+
+// Detection represents how the security stack responded to an attack step
+type Detection string
+
+// Known detection outcomes
+const (
+	DetectionNone      Detection = "none"
+	DetectionLogged    Detection = "logged"
+	DetectionDetected  Detection = "detected"
+	DetectionPrevented Detection = "prevented"
+)
+
+var detections = []Detection{DetectionNone, DetectionLogged, DetectionDetected, DetectionPrevented}
+
+// ParseDetection parses a known detection outcome, case-insensitively
+func ParseDetection(s string) (Detection, error) {
+	return parseEnum("detection", Detection(normalizeEnum(s)), detections)
+}
+
+// Valid reports whether d is a known detection outcome
+func (d Detection) Valid() bool {
+	return slices.Contains(detections, d)
+}
+
+// UnmarshalText accepts any value, matching known outcomes case-insensitively and keeping
+// other values as sent
+func (d *Detection) UnmarshalText(text []byte) error {
+	*d = unmarshalEnum(text, detections)
+	return nil
+}
+
+// EnumStrings converts enum values to plain strings, e.g. for query params
+func EnumStrings[T ~string](values []T) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	return strs
+}
+
+// normalizeEnum trims and lowercases an enum value
+func normalizeEnum(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// unmarshalEnum returns the known value matching text case-insensitively, or text unchanged
+func unmarshalEnum[T ~string](text []byte, known []T) T {
+	if i := slices.Index(known, T(normalizeEnum(string(text)))); i >= 0 {
+		return known[i]
+	}
+	return T(text)
+}
+
+// parseEnum returns value if it is one of known, otherwise an error listing the known values
+func parseEnum[T ~string](kind string, value T, known []T) (T, error) {
+	if !slices.Contains(known, value) {
+		return value, fmt.Errorf("invalid %s '%s': must be one of %s", kind, value, strings.Join(EnumStrings(known), ", "))
+	}
+	return value, nil
+}
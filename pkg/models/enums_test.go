@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExecutionStatusUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ExecutionStatus
+	}{
+		{`"finished"`, StatusFinished},
+		{`"InProgress"`, StatusInProgress},
+		{`" UNKNOWN "`, StatusUnknown},
+		{`"Canceled"`, "Canceled"},
+		{`"running"`, "running"},
+		{`""`, ""},
+	}
+
+	for _, tt := range tests {
+		var got ExecutionStatus
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEnumsRoundTrip(t *testing.T) {
+	// Values the SDK does not know are kept exactly as sent
+	in := `{"status":"Paused","status_state":"finished","execution_type":"Cloud","stager_mode":"MSI"}`
+
+	var e GetExecutionResponse
+	if err := json.Unmarshal([]byte(in), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Status != "Paused" || e.StatusState != StatusFinished || e.ExecutionType != "Cloud" || e.Status.Valid() {
+		t.Errorf("unmarshalled %+v", e)
+	}
+
+	out, err := json.Marshal(struct {
+		Status        ExecutionStatus `json:"status"`
+		StatusState   ExecutionStatus `json:"status_state"`
+		ExecutionType ExecutionType   `json:"execution_type"`
+		StagerMode    StagerMode      `json:"stager_mode"`
+	}{e.Status, e.StatusState, e.ExecutionType, "MSI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("Marshal() = %s, want %s", out, in)
+	}
+
+	var step GetExecutionResponseAssetStep
+	if err := json.Unmarshal([]byte(`{"severity":"HIGH","detection":"Quarantined"}`), &step); err != nil {
+		t.Fatal(err)
+	}
+	if step.Severity != SeverityHigh || step.Detection != "Quarantined" {
+		t.Errorf("step severity = %q, detection = %q", step.Severity, step.Detection)
+	}
+}
+
+func TestParseExecutionStatus(t *testing.T) {
+	for _, s := range []string{"finished", "INPROGRESS", " unknown "} {
+		if _, err := ParseExecutionStatus(s); err != nil {
+			t.Errorf("ParseExecutionStatus(%q) error = %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "failed", "in_progress", "completed"} {
+		if _, err := ParseExecutionStatus(s); err == nil {
+			t.Errorf("ParseExecutionStatus(%q) succeeded", s)
+		}
+	}
+}
+
+func TestExecutionStatusLifecycle(t *testing.T) {
+	if !StatusFinished.IsTerminal() || StatusInProgress.IsTerminal() || StatusUnknown.IsTerminal() || ExecutionStatus("failed").IsTerminal() {
+		t.Error("only finished is terminal")
+	}
+	if !StatusInProgress.IsRunning() || StatusFinished.IsRunning() {
+		t.Error("only inprogress is running")
+	}
+
+	tests := []struct {
+		from, to ExecutionStatus
+		want     bool
+	}{
+		{StatusInProgress, StatusFinished, true},
+		{StatusFinished, StatusInProgress, false},
+		{StatusInProgress, StatusUnknown, false},
+		{StatusUnknown, StatusFinished, true},
+		{StatusFinished, StatusFinished, true},
+		{StatusFinished, "paused", true},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlTemplateText string
+
+// htmlTemplate renders the self-contained HTML report
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap(templateFuncs)).Parse(htmlTemplateText))
+
+// HTMLOpts represents options for rendering an HTML report
+type HTMLOpts struct {
+	Title      string       // Page title, defaults to the attack name
+	Techniques TechniqueMap // Maps actions to MITRE ATT&CK techniques, the MITRE section is omitted if nil
+	Now        time.Time    // Generation time shown in the report, defaults to time.Now
+}
+
+// WriteHTML writes an execution report to w as a single HTML page with inline styles and no
+// external resources, so it can be viewed offline or sent by email
+func WriteHTML(w io.Writer, e models.GetExecutionResponse, opts HTMLOpts) error {
+	if err := htmlTemplate.Execute(w, newExecutionView(e, opts.Title, opts.Techniques, opts.Now)); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
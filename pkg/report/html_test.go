@@ -0,0 +1,27 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteHTMLNestedSteps(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, nestedExecution(), HTMLOpts{Now: time.Unix(0, 0)}); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Composite &gt; Child", "Composite &gt; Prevented"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML report does not contain %q", want)
+		}
+	}
+
+	v := newExecutionView(nestedExecution(), "", nil, time.Unix(0, 0))
+	if v.Summary.Steps != 4 || v.Summary.Undetected != 2 || len(v.Assets[0].Steps) != 4 || v.Assets[0].Steps[2].Depth != 1 {
+		t.Errorf("execution view summary = %+v, want the nested steps counted", v.Summary)
+	}
+}
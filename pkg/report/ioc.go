@@ -0,0 +1,141 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// Sources of an indicator extracted by IOCs
+const (
+	IOCSourceIOC            = "ioc"            // An IOC entry of a step
+	IOCSourceRuleHash       = "rule_hash"      // A hash of the content of a rule matched by a step, not of a file
+	IOCSourceRecommendation = "recommendation" // A rule recommended for detecting a step
+)
+
+// IOCRecord represents an indicator extracted from a step of an execution
+type IOCRecord struct {
+	ExecutionID string     `json:"execution_id"`
+	AssetID     string     `json:"asset_id,omitempty"`
+	Hostname    string     `json:"hostname,omitempty"`
+	ActionID    string     `json:"action_id,omitempty"`
+	StepName    string     `json:"step_name,omitempty"`
+	Source      string     `json:"source"`         // One of the IOCSource constants
+	Type        string     `json:"type"`           // IOC type, rule-<algorithm> for rule hashes or rule type, lowercased
+	Value       string     `json:"value"`          // IOC value, hash of the rule content or rule content
+	Name        string     `json:"name,omitempty"` // Name of the rule the value comes from
+	FirstSeen   *time.Time `json:"first_seen,omitempty"`
+}
+
+// IOCs extracts the IOC entries, rule hashes and recommended rules of every step of the given executions,
+// including the action steps nested in composite actions.
+// Rule hashes identify the content of a detection rule rather than a file artifact, so their type is
+// prefixed with "rule-" to keep them apart from file hashes reported as IOC entries.
+func IOCs(reports ...models.GetExecutionResponse) []IOCRecord {
+	var records []IOCRecord
+
+	for _, e := range reports {
+		for v := range executions.Steps(e) {
+			step, asset := v.Step, v.Asset
+			base := IOCRecord{
+				ExecutionID: e.ID,
+				AssetID:     asset.AssetID,
+				Hostname:    asset.Hostname,
+				ActionID:    step.ActionID,
+				StepName:    step.Name,
+				FirstSeen:   step.CreatedAt,
+			}
+			if base.FirstSeen == nil {
+				base.FirstSeen = e.CreatedAt
+			}
+
+			for _, ioc := range step.IOC {
+				value := iocValue(ioc.IOC)
+				if value == "" {
+					continue
+				}
+				r := base
+				r.Source, r.Type, r.Value = IOCSourceIOC, strings.ToLower(ioc.IOCType), value
+				if ioc.CreatedAt != nil {
+					r.FirstSeen = ioc.CreatedAt
+				}
+				records = append(records, r)
+			}
+
+			for _, rule := range step.Rules {
+				if rule.Hash == nil {
+					continue
+				}
+				for _, h := range []struct{ algo, value string }{
+					{"md5", rule.Hash.MD5}, {"sha1", rule.Hash.SHA1}, {"sha256", rule.Hash.SHA256},
+				} {
+					if h.value == "" {
+						continue
+					}
+					r := base
+					r.Source, r.Type, r.Value, r.Name = IOCSourceRuleHash, "rule-"+h.algo, h.value, rule.Name
+					records = append(records, r)
+				}
+			}
+
+			for _, rec := range step.Recommendation {
+				for _, rule := range rec.Rules {
+					if rule.Value == "" {
+						continue
+					}
+					r := base
+					r.Source, r.Type, r.Value, r.Name = IOCSourceRecommendation, strings.ToLower(valueOr(rule.Type, rec.Name)), rule.Value, rule.Name
+					records = append(records, r)
+				}
+			}
+		}
+	}
+
+	return records
+}
+
+// WriteIOCsCSV writes indicators to w as CSV with a header row
+func WriteIOCsCSV(w io.Writer, records []IOCRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"execution_id", "asset_id", "hostname", "action_id", "step_name", "source", "type", "value", "name", "first_seen"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, r := range records {
+		firstSeen := ""
+		if r.FirstSeen != nil {
+			firstSeen = r.FirstSeen.UTC().Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{r.ExecutionID, r.AssetID, r.Hostname, r.ActionID, r.StepName, r.Source, r.Type, r.Value, r.Name, firstSeen}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// iocValue formats the value of an IOC entry, which may be a string or a JSON value
+func iocValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
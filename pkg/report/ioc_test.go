@@ -0,0 +1,108 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// iocExecution returns an execution with a step carrying an IOC, a matched rule and recommended rules
+func iocExecution() models.GetExecutionResponse {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return models.GetExecutionResponse{
+		ID:        "e1",
+		CreatedAt: &created,
+		Assets: []models.AssetExecutionDetails{{
+			AssetID:  "a1",
+			Hostname: "host-1",
+			Steps: []models.GetExecutionResponseAssetStep{{
+				ActionID: "act-1",
+				Name:     "Download payload",
+				IOC: []models.IOC{
+					{IOCType: "SHA256", IOC: "abc"},
+					{IOCType: "ipv4", IOC: " 10.0.0.1 "},
+					{IOCType: "unknown", IOC: "value"},
+				},
+				Rules: []models.Rule{{Name: "Matched rule", Hash: &models.Hash{MD5: "d41d8cd9", SHA256: "e3b0c442"}}},
+				Recommendation: []models.Recommendation{{
+					Name: "Sigma Rules",
+					Rules: []models.Rule{
+						{Name: "Sigma rule", Value: "title: payload"},
+						{Name: "YARA rule", Type: "YARA", Value: "rule payload {}"},
+						{Name: "Splunk search", Type: "spl", Value: "index=main"},
+					},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestIOCs(t *testing.T) {
+	records := IOCs(iocExecution())
+
+	want := []struct{ source, typ, value string }{
+		{IOCSourceIOC, "sha256", "abc"},
+		{IOCSourceIOC, "ipv4", "10.0.0.1"},
+		{IOCSourceIOC, "unknown", "value"},
+		{IOCSourceRuleHash, "rule-md5", "d41d8cd9"},
+		{IOCSourceRuleHash, "rule-sha256", "e3b0c442"},
+		{IOCSourceRecommendation, "sigma rules", "title: payload"},
+		{IOCSourceRecommendation, "yara", "rule payload {}"},
+		{IOCSourceRecommendation, "spl", "index=main"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("IOCs() = %d records, want %d: %+v", len(records), len(want), records)
+	}
+	for i, w := range want {
+		r := records[i]
+		if r.Source != w.source || r.Type != w.typ || r.Value != w.value {
+			t.Errorf("record %d = %s %s %q, want %s %s %q", i, r.Source, r.Type, r.Value, w.source, w.typ, w.value)
+		}
+		if r.ExecutionID != "e1" || r.Hostname != "host-1" || r.FirstSeen == nil {
+			t.Errorf("record %d = %+v, want the step and execution details", i, r)
+		}
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteIOCsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIOCsCSV(&buf, IOCs(iocExecution())); err != nil {
+		t.Fatalf("WriteIOCsCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	if len(rows) != 9 || rows[0][0] != "execution_id" {
+		t.Fatalf("WriteIOCsCSV() = %d rows, want a header and 8 records", len(rows))
+	}
+	if rows[4][5] != IOCSourceRuleHash || rows[4][6] != "rule-md5" || rows[4][9] != "2026-01-02T03:04:05Z" {
+		t.Errorf("rule hash row = %v", rows[4])
+	}
+
+	if err := WriteIOCsCSV(failingWriter{}, IOCs(iocExecution())); err == nil {
+		t.Error("WriteIOCsCSV() to a failing writer succeeded")
+	}
+}
+
+func TestIOCsNestedSteps(t *testing.T) {
+	e := nestedExecution()
+	e.Assets[0].Steps[0].ActionSteps[1].IOC = []models.IOC{{IOCType: "domain", IOC: "example.com"}}
+
+	records := IOCs(e)
+	if len(records) != 1 || records[0].ActionID != "act-2" || records[0].Value != "example.com" {
+		t.Errorf("IOCs() = %+v, want the IOC of the nested step", records)
+	}
+}
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is a JUnit test suite, one per asset of an execution
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	ID         string          `xml:"id,attr,omitempty"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       float64         `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	Cases      []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitProperty is a name/value pair attached to a test suite
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestCase is a JUnit test case, one per step of an asset including nested action steps
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut *JUnitOutput  `xml:"system-out,omitempty"`
+}
+
+// JUnitMessage is the failure or skipped element of a test case
+type JUnitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+// JUnitOutput is the system-out element of a test case
+type JUnitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// JUnit converts an execution report into JUnit test suites. Each asset becomes a test suite and each
+// step a test case, which fails when the attack succeeded without being detected and is skipped when
+// the step has no result yet. Action steps nested in composite actions are test cases named after
+// their enclosing steps.
+func JUnit(e models.GetExecutionResponse) JUnitTestSuites {
+	suites := JUnitTestSuites{Name: e.AttackName}
+	if suites.Name == "" {
+		suites.Name = e.ID
+	}
+
+	suiteIndex := map[*models.AssetExecutionDetails]int{}
+	for i := range e.Assets {
+		asset := &e.Assets[i]
+		suiteIndex[asset] = i
+		suite := JUnitTestSuite{
+			Name: hostname(*asset),
+			ID:   asset.AssetID,
+			Properties: []JUnitProperty{
+				{Name: "execution_id", Value: e.ID},
+				{Name: "asset_id", Value: asset.AssetID},
+				{Name: "platform", Value: asset.Platform},
+				{Name: "ipaddr", Value: asset.IPAddr},
+			},
+		}
+		if e.CreatedAt != nil {
+			suite.Timestamp = e.CreatedAt.UTC().Format("2006-01-02T15:04:05")
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	for v := range executions.Steps(e) {
+		suite := &suites.Suites[suiteIndex[v.Asset]]
+		tc := junitTestCase(suite.Name, v)
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+		suite.Tests++
+		suite.Time += tc.Time
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Time += suite.Time
+	}
+
+	return suites
+}
+
+// WriteJUnit writes an execution report to w as a JUnit XML document
+func WriteJUnit(w io.Writer, e models.GetExecutionResponse) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(JUnit(e)); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitTestCase converts a visited step into a test case of the suite named className
+func junitTestCase(className string, v executions.StepVisit) JUnitTestCase {
+	step := *v.Step
+	name := stepLabel(v)
+	if step.ActionID != "" && step.ActionID != valueOr(step.Name, step.ActionID) {
+		name = fmt.Sprintf("%s (%s)", name, step.ActionID)
+	}
+
+	tc := JUnitTestCase{
+		Name:      name,
+		ClassName: className,
+		Time:      stepDuration(step).Seconds(),
+		SystemOut: &JUnitOutput{Text: junitStepOutput(step)},
+	}
+
+	switch {
+	case step.Success == nil:
+		tc.Skipped = &JUnitMessage{Message: "step has no result"}
+	case Undetected(step):
+		message := "attack succeeded without being detected"
+		if models.IsTrue(step.Logged) {
+			message = "attack succeeded and was logged but not detected"
+		}
+		tc.Failure = &JUnitMessage{
+			Message: message,
+			Type:    "undetected",
+			Text:    junitFailureText(step),
+		}
+	}
+
+	return tc
+}
+
+// junitFailureText describes an undetected step for the failure element
+func junitFailureText(step models.GetExecutionResponseAssetStep) string {
+	var b strings.Builder
+	if step.Description != "" {
+		fmt.Fprintf(&b, "%s\n", step.Description)
+	}
+	if step.Severity != "" {
+		fmt.Fprintf(&b, "Severity: %s\n", step.Severity)
+	}
+	if step.Mitigation != "" {
+		fmt.Fprintf(&b, "Mitigation: %s\n", step.Mitigation)
+	}
+	return b.String()
+}
+
+// junitStepOutput lists the outcome, correlations and mitigations of a step for the system-out element
+func junitStepOutput(step models.GetExecutionResponseAssetStep) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Success: %s, Detected: %s, Logged: %s\n",
+		formatBool(step.Success), formatBool(step.Detected), formatBool(step.Logged))
+	if step.Severity != "" {
+		fmt.Fprintf(&b, "Severity: %s\n", step.Severity)
+	}
+
+	if len(step.Correlations) > 0 {
+		b.WriteString("\nCorrelations:\n")
+		for _, c := range step.Correlations {
+			fmt.Fprintf(&b, "  - [%s] %s (Source: %s", c.Severity, c.Name, c.Source)
+			if !c.DetectionTime.IsZero() {
+				fmt.Fprintf(&b, ", Detected: %s", c.DetectionTime.Format(time.RFC3339))
+			}
+			b.WriteString(")\n")
+		}
+	}
+
+	if len(step.Mitigations) > 0 {
+		b.WriteString("\nMitigations:\n")
+		for _, m := range step.Mitigations {
+			fmt.Fprintf(&b, "  - %s: %s\n", m.Name, m.Description)
+		}
+	}
+
+	return b.String()
+}
+
+// formatBool formats an optional bool, with unset values as N/A
+func formatBool(b *bool) string {
+	if b == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%t", *b)
+}
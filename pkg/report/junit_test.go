@@ -0,0 +1,65 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// nestedExecution returns an execution whose composite action runs an undetected action that is also
+// run at the top level
+func nestedExecution() models.GetExecutionResponse {
+	return models.GetExecutionResponse{
+		ID: "e1",
+		Assets: []models.AssetExecutionDetails{
+			{
+				AssetID:  "a1",
+				Hostname: "host-1",
+				Steps: []models.GetExecutionResponseAssetStep{
+					{
+						ActionID: "parent", Name: "Composite", Success: boolPtr(true), Detected: boolPtr(true),
+						ActionSteps: []models.GetExecutionResponseAssetStep{
+							{ActionID: "act-1", Name: "Prevented", Success: boolPtr(false)},
+							{ActionID: "act-2", Name: "Child", Success: boolPtr(true), Detected: boolPtr(false)},
+						},
+					},
+					{ActionID: "act-2", Success: boolPtr(true), Detected: boolPtr(false)},
+				},
+			},
+			{AssetID: "a2"},
+		},
+	}
+}
+
+func TestJUnitNestedSteps(t *testing.T) {
+	suites := JUnit(nestedExecution())
+
+	if len(suites.Suites) != 2 || suites.Tests != 4 || suites.Failures != 2 {
+		t.Fatalf("JUnit() = %d suites, %d tests and %d failures, want 2, 4 and 2", len(suites.Suites), suites.Tests, suites.Failures)
+	}
+	if s := suites.Suites[1]; s.Name != "a2" || s.Tests != 0 {
+		t.Errorf("suite of an asset without steps = %+v", s)
+	}
+
+	want := []string{"Composite (parent)", "Composite > Prevented (act-1)", "Composite > Child (act-2)", "act-2"}
+	cases := suites.Suites[0].Cases
+	for i, name := range want {
+		if cases[i].Name != name {
+			t.Errorf("test case %d = %q, want %q", i, cases[i].Name, name)
+		}
+	}
+	if cases[1].Failure != nil || cases[2].Failure == nil {
+		t.Errorf("nested test cases failures = %v, %v", cases[1].Failure, cases[2].Failure)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, nestedExecution()); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+	var decoded JUnitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.Tests != 4 {
+		t.Errorf("WriteJUnit() output is not the JUnit report: %v", err)
+	}
+}
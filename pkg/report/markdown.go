@@ -0,0 +1,123 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+//go:embed templates/*.md.tmpl
+var markdownTemplates embed.FS
+
+// Names of the Markdown templates, which can be replaced with MarkdownRenderer.Override
+const (
+	MarkdownExecutionTemplate = "execution.md.tmpl" // Renders a GetExecutionResponse
+	MarkdownStepsTemplate     = "steps.md.tmpl"     // Renders a slice of ExecutionStepDetections
+	MarkdownPacksTemplate     = "packs.md.tmpl"     // Renders a slice of PackRun
+)
+
+// MarkdownOpts represents options for rendering a Markdown execution report
+type MarkdownOpts struct {
+	Title      string       // Report title, defaults to the attack name
+	Techniques TechniqueMap // Maps actions to MITRE ATT&CK techniques, the MITRE section is omitted if nil
+	Now        time.Time    // Generation time shown in the report, defaults to time.Now
+}
+
+// MarkdownRenderer renders execution, step and pack reports as Markdown using Go text/template templates.
+// The built-in templates can be replaced, and templates may use the functions formatTime, percent, join,
+// formatBool, outcome, cell, fence and inc. The "step" template renders the details of a single step.
+type MarkdownRenderer struct {
+	tmpl *template.Template
+}
+
+// NewMarkdownRenderer returns a renderer using the built-in templates
+func NewMarkdownRenderer() *MarkdownRenderer {
+	funcs := template.FuncMap(maps.Clone(templateFuncs))
+	funcs["cell"] = markdownCell
+	funcs["fence"] = markdownFence
+	funcs["inc"] = func(i int) int { return i + 1 }
+
+	r := &MarkdownRenderer{tmpl: template.New("markdown").Funcs(funcs)}
+	names, _ := fs.Glob(markdownTemplates, "templates/*.md.tmpl")
+	for _, name := range names {
+		text, err := markdownTemplates.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+		if err := r.Override(path.Base(name), string(text)); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Override replaces the template called name with text. text may also {{define}} helper templates.
+// CRLF line endings are converted to LF so that reports use a single line ending.
+func (r *MarkdownRenderer) Override(name, text string) error {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if _, err := r.tmpl.New(name).Parse(text); err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	return nil
+}
+
+// OverrideFile replaces the template called name with the contents of the file at path
+func (r *MarkdownRenderer) OverrideFile(name, path string) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template %s: %w", filepath.Base(path), err)
+	}
+	return r.Override(name, string(text))
+}
+
+// Execution writes an execution report with summary tables, per-step detection status,
+// mitigations and recommended rules
+func (r *MarkdownRenderer) Execution(w io.Writer, e models.GetExecutionResponse, opts MarkdownOpts) error {
+	return r.execute(w, MarkdownExecutionTemplate, newExecutionView(e, opts.Title, opts.Techniques, opts.Now))
+}
+
+// Steps writes a report of the steps returned by executions.GetExecutionStepReport
+func (r *MarkdownRenderer) Steps(w io.Writer, steps []models.ExecutionStepDetections) error {
+	return r.execute(w, MarkdownStepsTemplate, newStepsView(steps))
+}
+
+// Packs writes a report of pack runs with their executions
+func (r *MarkdownRenderer) Packs(w io.Writer, packs []models.PackRun) error {
+	return r.execute(w, MarkdownPacksTemplate, newPacksView(packs))
+}
+
+// execute renders the template called name
+func (r *MarkdownRenderer) execute(w io.Writer, name string, data any) error {
+	if err := r.tmpl.ExecuteTemplate(w, name, data); err != nil {
+		return fmt.Errorf("failed to render Markdown report: %w", err)
+	}
+	return nil
+}
+
+// markdownCell escapes a value for use in a Markdown table cell
+func markdownCell(v any) string {
+	s := fmt.Sprint(v)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// markdownFence wraps text in a fenced code block with the given info string, using a fence
+// longer than any run of backticks in the text
+func markdownFence(info, text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s", fence, strings.ToLower(info), strings.TrimRight(text, "\n"), fence)
+}
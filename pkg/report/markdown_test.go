@@ -0,0 +1,209 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// markdownExecution returns the nested execution with a detection and a recommended rule on its first step
+func markdownExecution() models.GetExecutionResponse {
+	e := nestedExecution()
+	e.AttackName = "Discovery | Lateral"
+	step := &e.Assets[0].Steps[0]
+	step.Correlations = []models.Correlation{{Name: "Credential dump", Source: "edr", Severity: "high", DetectionTime: time.Unix(60, 0).UTC()}}
+	step.Recommendation = []models.Recommendation{{
+		Name:  "Sigma",
+		Rules: []models.Rule{{Name: "Detect dump", Type: "YAML", Value: "title: dump\ndescription: ```quoted```\n"}},
+	}}
+	return e
+}
+
+// renderMarkdown renders with fn and returns the output
+func renderMarkdown(t *testing.T, fn func(*bytes.Buffer) error) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := fn(&buf); err != nil {
+		t.Fatalf("render error = %v", err)
+	}
+	return buf.String()
+}
+
+// assertContains reports every want missing from out
+func assertContains(t *testing.T, out string, want ...string) {
+	t.Helper()
+
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("output does not contain %q:\n%s", w, out)
+		}
+	}
+}
+
+func TestMarkdownExecution(t *testing.T) {
+	r := NewMarkdownRenderer()
+	opts := MarkdownOpts{
+		Techniques: TechniqueMap{"act-2": {{ID: "T1003", Tactics: []string{"Credential Access"}}}},
+		Now:        time.Unix(0, 0),
+	}
+	out := renderMarkdown(t, func(buf *bytes.Buffer) error { return r.Execution(buf, markdownExecution(), opts) })
+
+	assertContains(t, out,
+		"# Discovery | Lateral\n",
+		"| 50.0% | 4 | 1 | 1 | 0 | 2 | 0 |",
+		"| host-1 |",
+		"## MITRE ATT&CK",
+		"| T1003 | Credential Access |",
+		"| Composite > Child | act-2 | undetected |  | T1003 |",
+		"#### Composite (detected)",
+		"- [high] Credential dump (Source: edr, ",
+		"**Recommended: Sigma**",
+		"````yaml\ntitle: dump\ndescription: ```quoted```\n````",
+	)
+	if strings.Contains(out, "\r") {
+		t.Error("report contains CRLF line endings")
+	}
+
+	// A title replaces the attack name, and the MITRE section needs techniques
+	out = renderMarkdown(t, func(buf *bytes.Buffer) error {
+		return r.Execution(buf, markdownExecution(), MarkdownOpts{Title: "Weekly", Now: time.Unix(0, 0)})
+	})
+	if !strings.HasPrefix(out, "# Weekly\n") || strings.Contains(out, "MITRE") {
+		t.Errorf("report without techniques:\n%s", out)
+	}
+}
+
+func TestMarkdownSteps(t *testing.T) {
+	steps := []models.ExecutionStepDetections{
+		{
+			GetExecutionResponseAssetStep: models.GetExecutionResponseAssetStep{
+				ActionID: "act-1", Name: "Dump | creds", Success: boolPtr(true), Detected: boolPtr(true),
+				Correlations: []models.Correlation{{Name: "alert"}},
+			},
+			AssetID: "a1", Hostname: "host-1",
+		},
+		{
+			GetExecutionResponseAssetStep: models.GetExecutionResponseAssetStep{ActionID: "act-2", Success: boolPtr(false)},
+			AssetID:                       "a2",
+		},
+	}
+	out := renderMarkdown(t, func(buf *bytes.Buffer) error { return NewMarkdownRenderer().Steps(buf, steps) })
+
+	assertContains(t, out,
+		"# Execution Steps",
+		"| 2 | 1 | 1 | 0 | 0 | 0 | 100.0% |",
+		`| 1 | host-1 | Dump \| creds | act-1 | detected | true | true | N/A |  | 1 |`,
+		"| 2 | a2 | act-2 | act-2 | prevented | false |",
+		"#### Dump | creds (detected)",
+	)
+}
+
+func TestMarkdownPacks(t *testing.T) {
+	created := "2026-03-01"
+	packs := []models.PackRun{
+		{
+			ID: "p1", Name: "Weekly", Description: "Weekly assessment", StatusState: models.StatusFinished,
+			Total: 4, Success: 3, Detected: 1, CreatedAt: &created,
+			Hostname:   []models.HostInfo{{Name: "host-1"}, {Name: "host-2"}},
+			Executions: []models.Execution{{ID: "e1", AttackName: "Discovery", Status: models.StatusFinished, Progress: 100, TotalAttacks: 4}},
+		},
+		{ID: "p2", Name: "Empty"},
+	}
+	out := renderMarkdown(t, func(buf *bytes.Buffer) error { return NewMarkdownRenderer().Packs(buf, packs) })
+
+	assertContains(t, out,
+		"# Assessment Reports",
+		"| Weekly | finished | 3/4 | 1 | 25.0% | 2026-03-01 |",
+		"| Empty |  | 0/0 | 0 | 0.0% | N/A |",
+		"## Weekly\n\nWeekly assessment",
+		"- **Hosts:** host-1, host-2",
+		"| e1 | Discovery | finished | 100.0% | 0.0% | 4 | 0 | 0 |",
+	)
+}
+
+func TestMarkdownOverride(t *testing.T) {
+	r := NewMarkdownRenderer()
+	if err := r.Override(MarkdownPacksTemplate, `{{range .}}{{template "pack" .}}{{end}}{{define "pack"}}- {{cell .Name}}: {{percent .DetectionRate}}
+{{end}}`); err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+
+	out := renderMarkdown(t, func(buf *bytes.Buffer) error {
+		return r.Packs(buf, []models.PackRun{{Name: "a|b", Total: 2, Detected: 1}})
+	})
+	if out != "- a\\|b: 50.0%\n" {
+		t.Errorf("overridden packs template = %q", out)
+	}
+
+	// Other templates are kept
+	out = renderMarkdown(t, func(buf *bytes.Buffer) error { return r.Steps(buf, nil) })
+	assertContains(t, out, "# Execution Steps")
+
+	if err := r.Override(MarkdownStepsTemplate, "{{.Missing"); err == nil {
+		t.Error("Override() with an invalid template succeeded")
+	}
+}
+
+func TestMarkdownOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "execution.md.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Title}}: {{.Summary.Steps}} steps\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewMarkdownRenderer()
+	if err := r.OverrideFile(MarkdownExecutionTemplate, path); err != nil {
+		t.Fatalf("OverrideFile() error = %v", err)
+	}
+	out := renderMarkdown(t, func(buf *bytes.Buffer) error {
+		return r.Execution(buf, nestedExecution(), MarkdownOpts{Title: "Custom"})
+	})
+	if out != "Custom: 4 steps\n" {
+		t.Errorf("overridden execution template = %q", out)
+	}
+
+	if err := r.OverrideFile(MarkdownExecutionTemplate, filepath.Join(t.TempDir(), "missing.tmpl")); err == nil || !strings.Contains(err.Error(), "missing.tmpl") {
+		t.Errorf("OverrideFile() of a missing file error = %v", err)
+	}
+}
+
+func TestMarkdownCell(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"plain", "plain"},
+		{"a|b|c", `a\|b\|c`},
+		{"line 1\nline 2", "line 1<br>line 2"},
+		{"line 1\r\nline 2", "line 1<br>line 2"},
+		{42, "42"},
+		{models.StatusFinished, "finished"},
+	}
+	for _, tt := range tests {
+		if got := markdownCell(tt.value); got != tt.want {
+			t.Errorf("markdownCell(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownFence(t *testing.T) {
+	tests := []struct {
+		name, info, text, want string
+	}{
+		{"plain", "YAML", "a: 1\n", "```yaml\na: 1\n```"},
+		{"no info", "", "text", "```\ntext\n```"},
+		{"inline backticks", "", "use `x`", "```\nuse `x`\n```"},
+		{"fence in text", "md", "```go\nx\n```", "````md\n```go\nx\n```\n````"},
+		{"longer fence in text", "", "`````", "``````\n`````\n``````"},
+	}
+	for _, tt := range tests {
+		if got := markdownFence(tt.info, tt.text); got != tt.want {
+			t.Errorf("%s: markdownFence() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models/mitre"
+)
+
+// Technique identifies a MITRE ATT&CK technique an action maps to
+type Technique struct {
+	ID      string   `json:"id"`      // Technique ID including the sub-technique, e.g. T1003.001
+	Tactics []string `json:"tactics"` // Tactics the technique belongs to
+}
+
+// TechniqueMap maps action and stager IDs to the MITRE ATT&CK techniques they exercise
+type TechniqueMap map[string][]Technique
+
+// NewTechniqueMap builds a TechniqueMap from the coverage returned by mitre.GetAllMitreCoverage
+func NewTechniqueMap(coverage []mitre.MitreTacticTechniqueWithActionAndStagers) TechniqueMap {
+	m := TechniqueMap{}
+	for _, c := range coverage {
+		t := Technique{ID: TechniqueID(c), Tactics: c.Tactics}
+		if len(t.Tactics) == 0 && c.TacticID != "" {
+			t.Tactics = []string{c.TacticID}
+		}
+		if t.ID == "" {
+			continue
+		}
+
+		for _, id := range slices.Concat(c.Actions, c.Stagers) {
+			if !slices.ContainsFunc(m[id], func(o Technique) bool { return o.ID == t.ID }) {
+				m[id] = append(m[id], t)
+			}
+		}
+	}
+	return m
+}
+
+// Lookup returns the techniques of an action or stager, sorted by ID
+func (m TechniqueMap) Lookup(id string) []Technique {
+	techniques := slices.Clone(m[id])
+	slices.SortFunc(techniques, func(a, b Technique) int { return strings.Compare(a.ID, b.ID) })
+	return techniques
+}
+
+// TechniqueID returns the full technique ID of a coverage entry, e.g. T1003.001
+func TechniqueID(c mitre.MitreTacticTechniqueWithActionAndStagers) string {
+	if c.AbsoluteID != "" {
+		return c.AbsoluteID
+	}
+	if c.SubTechniqueID != "" && !strings.Contains(c.SubTechniqueID, ".") {
+		return c.TechniqueID + "." + c.SubTechniqueID
+	}
+	if c.SubTechniqueID != "" {
+		return c.SubTechniqueID
+	}
+	return c.TechniqueID
+}
@@ -0,0 +1,68 @@
+// Package report renders execution reports into formats consumed by CI systems and other tools
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// Outcome summarizes how the security stack responded to a step
+type Outcome string
+
+// Step outcomes, from best to worst
+const (
+	OutcomePrevented  Outcome = "prevented"  // The attack did not succeed
+	OutcomeDetected   Outcome = "detected"   // The attack succeeded and was detected
+	OutcomeLogged     Outcome = "logged"     // The attack succeeded and was only logged
+	OutcomeUndetected Outcome = "undetected" // The attack succeeded without being detected or logged
+	OutcomePending    Outcome = "pending"    // The step has no result yet
+)
+
+// StepOutcome classifies the outcome of a step
+func StepOutcome(step models.GetExecutionResponseAssetStep) Outcome {
+	switch {
+	case step.Success == nil:
+		return OutcomePending
+	case !*step.Success:
+		return OutcomePrevented
+	case models.IsTrue(step.Detected):
+		return OutcomeDetected
+	case models.IsTrue(step.Logged):
+		return OutcomeLogged
+	default:
+		return OutcomeUndetected
+	}
+}
+
+// Undetected reports whether a step succeeded without being detected by the security stack
+func Undetected(step models.GetExecutionResponseAssetStep) bool {
+	return models.IsTrue(step.Success) && !models.IsTrue(step.Detected)
+}
+
+// hostname returns the hostname of an asset, falling back to its ID
+func hostname(a models.AssetExecutionDetails) string {
+	if a.Hostname != "" {
+		return a.Hostname
+	}
+	return a.AssetID
+}
+
+// stepDuration returns the time between a step's creation and last update, or 0 if unknown
+func stepDuration(step models.GetExecutionResponseAssetStep) time.Duration {
+	if step.CreatedAt == nil || step.UpdatedAt == nil || step.UpdatedAt.Before(*step.CreatedAt) {
+		return 0
+	}
+	return step.UpdatedAt.Sub(*step.CreatedAt)
+}
+
+// stepLabel names a visited step after its name or action, preceded by the steps enclosing it
+func stepLabel(v executions.StepVisit) string {
+	labels := make([]string, 0, len(v.Parents)+1)
+	for _, p := range v.Parents {
+		labels = append(labels, valueOr(p.Name, p.ActionID))
+	}
+	return strings.Join(append(labels, valueOr(v.Step.Name, v.Step.ActionID)), " > ")
+}
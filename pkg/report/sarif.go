@@ -0,0 +1,296 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// SARIF format version and schema written by WriteSARIF
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the root object of a SARIF 2.1.0 log
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of the analysis tool, one per execution
+type SARIFRun struct {
+	Tool              SARIFTool               `json:"tool"`
+	AutomationDetails *SARIFAutomationDetails `json:"automationDetails,omitempty"`
+	Results           []SARIFResult           `json:"results"`
+	Properties        map[string]any          `json:"properties,omitempty"`
+	Invocations       []SARIFInvocation       `json:"invocations,omitempty"`
+}
+
+// SARIFTool describes the tool that produced the results
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that produced the results, with the rules they refer to
+type SARIFDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri,omitempty"`
+	Rules          []SARIFReportingDescriptor `json:"rules"`
+}
+
+// SARIFAutomationDetails identifies the run, used by consumers to track results across runs
+type SARIFAutomationDetails struct {
+	ID string `json:"id"`
+}
+
+// SARIFInvocation describes the execution the results were produced from
+type SARIFInvocation struct {
+	ExecutionSuccessful bool   `json:"executionSuccessful"`
+	StartTimeUTC        string `json:"startTimeUtc,omitempty"`
+	EndTimeUTC          string `json:"endTimeUtc,omitempty"`
+}
+
+// SARIFReportingDescriptor is a rule, one per action with an undetected step
+type SARIFReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *SARIFMessage           `json:"shortDescription,omitempty"`
+	FullDescription      *SARIFMessage           `json:"fullDescription,omitempty"`
+	Help                 *SARIFMessage           `json:"help,omitempty"`
+	DefaultConfiguration *SARIFRuleConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]any          `json:"properties,omitempty"`
+}
+
+// SARIFRuleConfiguration is the default configuration of a rule
+type SARIFRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain text message with an optional Markdown rendering
+type SARIFMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// SARIFResult is a finding, one per step that succeeded without being detected
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// SARIFLocation is where a result was found, the asset the step ran on. Assets are not files,
+// so results only have logical locations.
+type SARIFLocation struct {
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// SARIFLogicalLocation is a named location that is not a file, such as an asset
+type SARIFLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// sarifFingerprint is the partial fingerprint identifying a step across executions
+const sarifFingerprint = "fourcoreStep/v1"
+
+// sarifUnnamedRuleID is the rule of steps with neither an action ID nor a name
+const sarifUnnamedRuleID = "unnamed-step"
+
+// SARIF converts an execution report into a SARIF log. Each step that succeeded without being detected,
+// including the action steps nested in composite actions, becomes a result with the asset as its
+// logical location, with a rule per action carrying its mitigations and recommended rules. Result
+// levels are mapped from the step severity.
+func SARIF(e models.GetExecutionResponse) SARIFLog {
+	run := SARIFRun{
+		Tool: SARIFTool{Driver: SARIFDriver{
+			Name:           "FourCore ATTACK",
+			InformationURI: "https://fourcore.io",
+			Rules:          []SARIFReportingDescriptor{},
+		}},
+		AutomationDetails: &SARIFAutomationDetails{ID: fmt.Sprintf("fourcore/%s/%s", valueOr(e.ChainID, "execution"), e.ID)},
+		Results:           []SARIFResult{},
+		Properties: map[string]any{
+			"executionId":   e.ID,
+			"attackName":    e.AttackName,
+			"executionType": e.ExecutionType,
+		},
+	}
+
+	// The API reports no failed status, so the run is successful once it has a report
+	invocation := SARIFInvocation{ExecutionSuccessful: true}
+	if e.CreatedAt != nil {
+		invocation.StartTimeUTC = e.CreatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if e.UpdatedAt != nil {
+		invocation.EndTimeUTC = e.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	run.Invocations = []SARIFInvocation{invocation}
+
+	ruleIndex := map[string]int{}
+	for v := range executions.Steps(e) {
+		step, asset := *v.Step, v.Asset
+		if !Undetected(step) {
+			continue
+		}
+		host := hostname(*asset)
+
+		ruleID := valueOr(step.ActionID, valueOr(step.Name, sarifUnnamedRuleID))
+		idx, ok := ruleIndex[ruleID]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[ruleID] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(ruleID, step))
+		}
+
+		label := valueOr(stepLabel(v), ruleID)
+		message := fmt.Sprintf("%s succeeded on %s without being detected", label, host)
+		if models.IsTrue(step.Logged) {
+			message = fmt.Sprintf("%s succeeded on %s and was logged but not detected", label, host)
+		}
+
+		// Nested steps are told apart from the same action run at the top level by their enclosing actions
+		fingerprint := asset.AssetID + "/" + ruleID
+		properties := map[string]any{
+			"executionId": e.ID,
+			"assetId":     asset.AssetID,
+			"platform":    asset.Platform,
+			"logged":      models.IsTrue(step.Logged),
+		}
+		if det := v.Detections(); det.Depth > 0 {
+			fingerprint = asset.AssetID + "/" + strings.Join(det.ParentActionIDs, "/") + "/" + ruleID
+			properties["depth"] = det.Depth
+			properties["parentActionIds"] = det.ParentActionIDs
+		}
+
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    ruleID,
+			RuleIndex: idx,
+			Level:     sarifLevel(step.Severity),
+			Message:   SARIFMessage{Text: message},
+			Locations: []SARIFLocation{{
+				LogicalLocations: []SARIFLogicalLocation{{Name: host, FullyQualifiedName: asset.AssetID, Kind: "resource"}},
+			}},
+			PartialFingerprints: map[string]string{sarifFingerprint: fingerprint},
+			Properties:          properties,
+		})
+	}
+
+	return SARIFLog{Version: SARIFVersion, Schema: SARIFSchema, Runs: []SARIFRun{run}}
+}
+
+// WriteSARIF writes an execution report to w as a SARIF 2.1.0 log
+func WriteSARIF(w io.Writer, e models.GetExecutionResponse) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(SARIF(e)); err != nil {
+		return fmt.Errorf("failed to encode SARIF report: %w", err)
+	}
+	return nil
+}
+
+// sarifRule builds the rule of an action from the first undetected step running it
+func sarifRule(id string, step models.GetExecutionResponseAssetStep) SARIFReportingDescriptor {
+	rule := SARIFReportingDescriptor{
+		ID:                   id,
+		Name:                 sarifRuleName(valueOr(step.Name, id)),
+		ShortDescription:     &SARIFMessage{Text: valueOr(step.Name, id)},
+		DefaultConfiguration: &SARIFRuleConfiguration{Level: sarifLevel(step.Severity)},
+		Properties: map[string]any{
+			"tags": []string{"security", "attack-simulation"},
+		},
+	}
+	if step.Description != "" {
+		rule.FullDescription = &SARIFMessage{Text: step.Description}
+	}
+	if score := sarifSecuritySeverity(step.Severity); score != "" {
+		rule.Properties["security-severity"] = score
+	}
+
+	var text, md strings.Builder
+	if step.Mitigation != "" {
+		fmt.Fprintf(&text, "%s\n", step.Mitigation)
+		fmt.Fprintf(&md, "%s\n\n", step.Mitigation)
+	}
+	if len(step.Mitigations) > 0 {
+		text.WriteString("Mitigations:\n")
+		md.WriteString("**Mitigations**\n\n")
+		for _, m := range step.Mitigations {
+			fmt.Fprintf(&text, "- %s: %s\n", m.Name, m.Description)
+			fmt.Fprintf(&md, "- **%s**: %s\n", m.Name, m.Description)
+		}
+		md.WriteString("\n")
+	}
+	for _, rec := range step.Recommendation {
+		fmt.Fprintf(&text, "Recommended %s:\n", rec.Name)
+		fmt.Fprintf(&md, "**Recommended: %s**\n\n", rec.Name)
+		for _, r := range rec.Rules {
+			fmt.Fprintf(&text, "%s (%s):\n%s\n", r.Name, r.Type, r.Value)
+			fmt.Fprintf(&md, "%s (%s):\n\n%s\n\n", r.Name, r.Type, markdownFence(r.Type, r.Value))
+		}
+	}
+	if text.Len() > 0 {
+		rule.Help = &SARIFMessage{Text: strings.TrimSpace(text.String()), Markdown: strings.TrimSpace(md.String())}
+	}
+
+	return rule
+}
+
+// sarifRuleName converts a step name into a PascalCase rule name, e.g. "Dump LSASS" to "DumpLSASS"
+func sarifRuleName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		first, size := utf8.DecodeRuneInString(word)
+		b.WriteRune(unicode.ToUpper(first))
+		b.WriteString(word[size:])
+	}
+	return b.String()
+}
+
+// sarifLevel maps a step severity to a SARIF result level
+func sarifLevel(s models.Severity) string {
+	switch s {
+	case models.SeverityCritical, models.SeverityHigh:
+		return "error"
+	case models.SeverityMedium:
+		return "warning"
+	case models.SeverityLow, models.SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// sarifSecuritySeverity maps a step severity to the numeric security-severity property used by
+// code scanning tools to rank results, or "" if the severity is unknown
+func sarifSecuritySeverity(s models.Severity) string {
+	switch s {
+	case models.SeverityCritical:
+		return "9.5"
+	case models.SeverityHigh:
+		return "8.0"
+	case models.SeverityMedium:
+		return "5.5"
+	case models.SeverityLow:
+		return "3.0"
+	case models.SeverityInfo:
+		return "1.0"
+	default:
+		return ""
+	}
+}
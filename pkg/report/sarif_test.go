@@ -0,0 +1,120 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSARIF(t *testing.T) {
+	undetected := func(actionID, name string, severity models.Severity) models.GetExecutionResponseAssetStep {
+		return models.GetExecutionResponseAssetStep{ActionID: actionID, Name: name, Severity: severity, Success: boolPtr(true), Detected: boolPtr(false)}
+	}
+	e := models.GetExecutionResponse{
+		ID: "e1",
+		Assets: []models.AssetExecutionDetails{{
+			AssetID:  "a1",
+			Hostname: "host-1",
+			Steps: []models.GetExecutionResponseAssetStep{
+				undetected("act-1", "Dump LSASS", models.SeverityHigh),
+				undetected("", "Énumérer les utilisateurs", models.SeverityLow),
+				undetected("", "", ""),
+				{ActionID: "act-2", Name: "Detected", Success: boolPtr(true), Detected: boolPtr(true)},
+			},
+		}},
+	}
+
+	log := SARIF(e)
+	run := log.Runs[0]
+	if len(run.Results) != 3 {
+		t.Fatalf("%d results, want 3", len(run.Results))
+	}
+
+	wantRules := []struct{ id, name string }{
+		{"act-1", "DumpLSASS"},
+		{"Énumérer les utilisateurs", "ÉnumérerLesUtilisateurs"},
+		{sarifUnnamedRuleID, "UnnamedStep"},
+	}
+	for i, want := range wantRules {
+		rule := run.Tool.Driver.Rules[i]
+		if rule.ID != want.id || rule.Name != want.name {
+			t.Errorf("rule %d = %s (%s), want %s (%s)", i, rule.ID, rule.Name, want.id, want.name)
+		}
+		result := run.Results[i]
+		if result.RuleID == "" || result.RuleID != rule.ID || result.RuleIndex != i {
+			t.Errorf("result %d refers to rule %q at %d", i, result.RuleID, result.RuleIndex)
+		}
+	}
+	if run.Results[0].Level != "error" || run.Results[1].Level != "note" || run.Results[2].Level != "warning" {
+		t.Errorf("result levels = %s, %s, %s", run.Results[0].Level, run.Results[1].Level, run.Results[2].Level)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, e); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "physicalLocation") || strings.Contains(buf.String(), "artifactLocation") {
+		t.Error("SARIF log has physical locations for assets")
+	}
+
+	var decoded struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					LogicalLocations []SARIFLogicalLocation `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	loc := decoded.Runs[0].Results[0].Locations[0].LogicalLocations[0]
+	if loc.Name != "host-1" || loc.FullyQualifiedName != "a1" || loc.Kind != "resource" {
+		t.Errorf("logical location = %+v", loc)
+	}
+}
+
+func TestSARIFRuleName(t *testing.T) {
+	tests := map[string]string{
+		"Dump LSASS":          "DumpLSASS",
+		"credential-dumping":  "CredentialDumping",
+		"über tool v2":        "ÜberToolV2",
+		"  ":                  "",
+		"日本語 テスト":             "日本語テスト",
+		"persistence_via_run": "PersistenceViaRun",
+	}
+	for in, want := range tests {
+		if got := sarifRuleName(in); got != want {
+			t.Errorf("sarifRuleName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSARIFNestedSteps(t *testing.T) {
+	log := SARIF(nestedExecution())
+	results := log.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("%d results, want the top-level and nested undetected steps", len(results))
+	}
+
+	// Steps are visited depth-first, so the nested step comes first
+	nested, top := results[0], results[1]
+	if top.RuleID != "act-2" || nested.RuleID != "act-2" || top.RuleIndex != nested.RuleIndex {
+		t.Errorf("results refer to rules %s and %s, want both to share act-2", top.RuleID, nested.RuleID)
+	}
+	if top.PartialFingerprints[sarifFingerprint] != "a1/act-2" || nested.PartialFingerprints[sarifFingerprint] != "a1/parent/act-2" {
+		t.Errorf("fingerprints = %v and %v", top.PartialFingerprints, nested.PartialFingerprints)
+	}
+	if nested.Message.Text != "Composite > Child succeeded on host-1 without being detected" || nested.Properties["depth"] != 1 {
+		t.Errorf("nested result = %+v", nested)
+	}
+}
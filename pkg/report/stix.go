@@ -0,0 +1,356 @@
+package report
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// STIXSpecVersion is the STIX version of the objects built by STIX
+const STIXSpecVersion = "2.1"
+
+// stixNamespace is the UUIDv5 namespace of deterministic STIX identifiers, as recommended by the STIX 2.1 specification
+var stixNamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+
+// STIXBundle is a STIX 2.1 bundle
+type STIXBundle struct {
+	Type    string       `json:"type"`
+	ID      string       `json:"id"`
+	Objects []STIXObject `json:"objects"`
+}
+
+// STIXObject is a STIX 2.1 domain or relationship object. Only the properties of the object's type are set.
+type STIXObject struct {
+	Type               string                  `json:"type"`
+	SpecVersion        string                  `json:"spec_version"`
+	ID                 string                  `json:"id"`
+	Created            string                  `json:"created"`
+	Modified           string                  `json:"modified"`
+	CreatedByRef       string                  `json:"created_by_ref,omitempty"`
+	Name               string                  `json:"name,omitempty"`
+	Description        string                  `json:"description,omitempty"`
+	Labels             []string                `json:"labels,omitempty"`
+	ExternalReferences []STIXExternalReference `json:"external_references,omitempty"`
+
+	// attack-pattern
+	KillChainPhases []STIXKillChainPhase `json:"kill_chain_phases,omitempty"`
+
+	// identity
+	IdentityClass string `json:"identity_class,omitempty"`
+
+	// report
+	Published   string   `json:"published,omitempty"`
+	ReportTypes []string `json:"report_types,omitempty"`
+	ObjectRefs  []string `json:"object_refs,omitempty"`
+
+	// indicator
+	IndicatorTypes []string `json:"indicator_types,omitempty"`
+	Pattern        string   `json:"pattern,omitempty"`
+	PatternType    string   `json:"pattern_type,omitempty"`
+	ValidFrom      string   `json:"valid_from,omitempty"`
+
+	// relationship
+	RelationshipType string `json:"relationship_type,omitempty"`
+	SourceRef        string `json:"source_ref,omitempty"`
+	TargetRef        string `json:"target_ref,omitempty"`
+}
+
+// STIXExternalReference refers to a source outside of STIX, such as a MITRE ATT&CK technique
+type STIXExternalReference struct {
+	SourceName string `json:"source_name"`
+	ExternalID string `json:"external_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// STIXKillChainPhase is a phase of a kill chain, the MITRE ATT&CK tactic of an attack pattern
+type STIXKillChainPhase struct {
+	KillChainName string `json:"kill_chain_name"`
+	PhaseName     string `json:"phase_name"`
+}
+
+// STIXOpts represents options for building a STIX bundle
+type STIXOpts struct {
+	Techniques TechniqueMap // Maps actions to MITRE ATT&CK techniques, attack patterns are omitted if nil
+	Now        time.Time    // Timestamp of objects whose execution has no creation time, defaults to time.Now
+}
+
+// stixLabel labels every object built from an attack simulation
+const stixLabel = "fourcore-attack-simulation"
+
+// STIX builds a STIX 2.1 bundle of the indicators of one or more executions. Each execution becomes a
+// report referencing an attack pattern per MITRE ATT&CK technique of its steps, including the action
+// steps nested in composite actions, and an indicator per IOC and recommended rule, which indicates the
+// attack patterns of its step. Identifiers are UUIDv5 derived from the content, so exporting the same
+// executions again yields the same objects. Rule hashes, IOCs whose type has no STIX pattern equivalent
+// and rules whose language is not a STIX pattern type are left out.
+func STIX(reports []models.GetExecutionResponse, opts STIXOpts) STIXBundle {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	b := &stixBuilder{index: map[string]int{}}
+	identity := b.add(STIXObject{
+		Type:          "identity",
+		ID:            stixID("identity", "FourCore ATTACK"),
+		Created:       stixTime(time.Unix(0, 0)),
+		Modified:      stixTime(time.Unix(0, 0)),
+		Name:          "FourCore ATTACK",
+		IdentityClass: "system",
+	})
+
+	var bundleKeys []string
+	for _, e := range reports {
+		created := opts.Now
+		if e.CreatedAt != nil {
+			created = *e.CreatedAt
+		}
+		ts := stixTime(created)
+
+		var refs []string
+		addRef := func(id string) {
+			if !slices.Contains(refs, id) {
+				refs = append(refs, id)
+			}
+		}
+
+		// Techniques are referenced even when their steps have no indicators
+		for v := range executions.Steps(e) {
+			for _, t := range opts.Techniques.Lookup(v.Step.ActionID) {
+				addRef(b.add(stixAttackPattern(t, identity, ts)))
+			}
+		}
+
+		for _, r := range IOCs(e) {
+			pattern, patternType := stixPattern(r)
+			if pattern == "" {
+				continue
+			}
+
+			validFrom := created
+			if r.FirstSeen != nil {
+				validFrom = *r.FirstSeen
+			}
+			indicator := b.add(STIXObject{
+				Type:           "indicator",
+				ID:             stixID("indicator", patternType+"|"+pattern),
+				Created:        ts,
+				Modified:       ts,
+				CreatedByRef:   identity,
+				Name:           stixIndicatorName(r),
+				Description:    fmt.Sprintf("Observed in attack simulation step %s on %s", valueOr(r.StepName, r.ActionID), valueOr(r.Hostname, r.AssetID)),
+				Labels:         []string{stixLabel},
+				IndicatorTypes: []string{"anomalous-activity"},
+				Pattern:        pattern,
+				PatternType:    patternType,
+				ValidFrom:      stixTime(validFrom),
+			})
+			addRef(indicator)
+
+			for _, t := range opts.Techniques.Lookup(r.ActionID) {
+				pattern := b.add(stixAttackPattern(t, identity, ts))
+				addRef(pattern)
+				addRef(b.add(STIXObject{
+					Type:             "relationship",
+					ID:               stixID("relationship", indicator+"|indicates|"+pattern),
+					Created:          ts,
+					Modified:         ts,
+					CreatedByRef:     identity,
+					RelationshipType: "indicates",
+					SourceRef:        indicator,
+					TargetRef:        pattern,
+				}))
+			}
+		}
+
+		if len(refs) == 0 {
+			// A report must reference at least one object
+			refs = append(refs, identity)
+		}
+
+		b.add(STIXObject{
+			Type:         "report",
+			ID:           stixID("report", e.ID),
+			Created:      ts,
+			Modified:     ts,
+			CreatedByRef: identity,
+			Name:         valueOr(e.AttackName, "Execution "+e.ID),
+			Description:  fmt.Sprintf("Indicators of FourCore ATTACK execution %s", e.ID),
+			Labels:       []string{stixLabel},
+			Published:    ts,
+			ReportTypes:  []string{"attack-pattern", "indicator"},
+			ObjectRefs:   refs,
+		})
+		bundleKeys = append(bundleKeys, e.ID)
+	}
+
+	return STIXBundle{
+		Type:    "bundle",
+		ID:      stixID("bundle", strings.Join(bundleKeys, ",")),
+		Objects: b.objects,
+	}
+}
+
+// WriteSTIX writes the STIX 2.1 bundle of one or more executions to w
+func WriteSTIX(w io.Writer, reports []models.GetExecutionResponse, opts STIXOpts) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(STIX(reports, opts)); err != nil {
+		return fmt.Errorf("failed to encode STIX bundle: %w", err)
+	}
+	return nil
+}
+
+// stixBuilder collects the objects of a bundle, skipping objects whose ID was already added
+type stixBuilder struct {
+	objects []STIXObject
+	index   map[string]int
+}
+
+// add adds an object unless one with the same ID exists, and returns its ID
+func (b *stixBuilder) add(o STIXObject) string {
+	if _, ok := b.index[o.ID]; !ok {
+		o.SpecVersion = STIXSpecVersion
+		b.index[o.ID] = len(b.objects)
+		b.objects = append(b.objects, o)
+	}
+	return o.ID
+}
+
+// stixAttackPattern builds the attack pattern of a MITRE ATT&CK technique
+func stixAttackPattern(t Technique, identity, ts string) STIXObject {
+	url := "https://attack.mitre.org/techniques/" + strings.ReplaceAll(t.ID, ".", "/") + "/"
+
+	var phases []STIXKillChainPhase
+	for _, tactic := range t.Tactics {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tactic)), " ", "-")
+		phases = append(phases, STIXKillChainPhase{KillChainName: "mitre-attack", PhaseName: name})
+	}
+
+	return STIXObject{
+		Type:            "attack-pattern",
+		ID:              stixID("attack-pattern", t.ID),
+		Created:         ts,
+		Modified:        ts,
+		CreatedByRef:    identity,
+		Name:            t.ID,
+		KillChainPhases: phases,
+		ExternalReferences: []STIXExternalReference{
+			{SourceName: "mitre-attack", ExternalID: t.ID, URL: url},
+		},
+	}
+}
+
+// stixPatternTypes maps rule types to the STIX pattern type vocabulary
+var stixPatternTypes = map[string]string{
+	"stix":     "stix",
+	"sigma":    "sigma",
+	"yara":     "yara",
+	"snort":    "snort",
+	"suricata": "suricata",
+	"pcre":     "pcre",
+	"regex":    "pcre",
+	"regexp":   "pcre",
+}
+
+// stixPattern builds the pattern and pattern type of an indicator, or "" if it has no STIX equivalent.
+// Recommended rules keep their own language, e.g. sigma or yara, as the pattern type. Rule hashes are
+// hashes of rule content rather than of files, so they are never indicators.
+func stixPattern(r IOCRecord) (pattern, patternType string) {
+	switch r.Source {
+	case IOCSourceRuleHash:
+		return "", ""
+	case IOCSourceRecommendation:
+		patternType, ok := stixPatternTypes[stixRuleType(r.Type)]
+		if !ok {
+			return "", ""
+		}
+		return r.Value, patternType
+	}
+
+	v := stixEscape(r.Value)
+	switch r.Type {
+	case "ip", "ipv4", "ip-addr", "ipv4-addr":
+		return fmt.Sprintf("[ipv4-addr:value = '%s']", v), "stix"
+	case "ipv6", "ipv6-addr":
+		return fmt.Sprintf("[ipv6-addr:value = '%s']", v), "stix"
+	case "domain", "domain-name", "hostname":
+		return fmt.Sprintf("[domain-name:value = '%s']", v), "stix"
+	case "url", "uri":
+		return fmt.Sprintf("[url:value = '%s']", v), "stix"
+	case "email", "email-addr":
+		return fmt.Sprintf("[email-addr:value = '%s']", v), "stix"
+	case "md5":
+		return fmt.Sprintf("[file:hashes.MD5 = '%s']", v), "stix"
+	case "sha1":
+		return fmt.Sprintf("[file:hashes.'SHA-1' = '%s']", v), "stix"
+	case "sha256":
+		return fmt.Sprintf("[file:hashes.'SHA-256' = '%s']", v), "stix"
+	case "file", "filename", "file-name":
+		return fmt.Sprintf("[file:name = '%s']", v), "stix"
+	case "registry", "registry-key":
+		return fmt.Sprintf("[windows-registry-key:key = '%s']", v), "stix"
+	case "process", "command", "command-line", "cmdline":
+		return fmt.Sprintf("[process:command_line = '%s']", v), "stix"
+	case "mutex":
+		return fmt.Sprintf("[mutex:name = '%s']", v), "stix"
+	default:
+		return "", ""
+	}
+}
+
+// stixRuleType normalizes the type of a recommended rule, which may be a name such as "Sigma Rules"
+func stixRuleType(typ string) string {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	typ = strings.TrimSuffix(typ, "s")
+	return strings.TrimSpace(strings.TrimSuffix(typ, "rule"))
+}
+
+// stixIndicatorName names an indicator after its value or rule, truncating long values to 80 characters
+func stixIndicatorName(r IOCRecord) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	value := r.Value
+	if utf8.RuneCountInString(value) > 80 {
+		value = string([]rune(value)[:77]) + "..."
+	}
+	return fmt.Sprintf("%s: %s", r.Type, value)
+}
+
+// stixEscape escapes a value for use in a quoted STIX pattern string
+func stixEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// stixTime formats a timestamp as required by STIX, in UTC with millisecond precision
+func stixTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// stixID returns the deterministic identifier of an object of type typ identified by name
+func stixID(typ, name string) string {
+	return typ + "--" + uuidV5(stixNamespace, typ+"|"+name)
+}
+
+// uuidV5 returns the name-based SHA-1 UUID of name in namespace, as defined by RFC 9562
+func uuidV5(namespace [16]byte, name string) string {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	var u [16]byte
+	copy(u[:], sum)
+	u[6] = (u[6] & 0x0f) | 0x50 // Version 5
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 9562 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+func TestSTIX(t *testing.T) {
+	bundle := STIX([]models.GetExecutionResponse{iocExecution()}, STIXOpts{
+		Techniques: TechniqueMap{"act-1": {{ID: "T1105", Tactics: []string{"Command and Control"}}}},
+	})
+
+	indicators := map[string]string{}
+	var reports, patterns, relationships int
+	for _, o := range bundle.Objects {
+		switch o.Type {
+		case "indicator":
+			indicators[o.Pattern] = o.PatternType
+		case "report":
+			reports++
+		case "attack-pattern":
+			patterns++
+		case "relationship":
+			relationships++
+		}
+	}
+
+	// Rule hashes, unknown IOC types and rule types outside of the STIX vocabulary are left out
+	want := map[string]string{
+		"[file:hashes.'SHA-256' = 'abc']": "stix",
+		"[ipv4-addr:value = '10.0.0.1']":  "stix",
+		"title: payload":                  "sigma",
+		"rule payload {}":                 "yara",
+	}
+	if len(indicators) != len(want) {
+		t.Errorf("indicators = %v, want %v", indicators, want)
+	}
+	for pattern, patternType := range want {
+		if indicators[pattern] != patternType {
+			t.Errorf("indicator %q has pattern type %q, want %q", pattern, indicators[pattern], patternType)
+		}
+	}
+	if reports != 1 || patterns != 1 || relationships != len(want) {
+		t.Errorf("%d reports, %d attack patterns and %d relationships, want 1, 1 and %d", reports, patterns, relationships, len(want))
+	}
+
+	// Identifiers are derived from the content
+	again := STIX([]models.GetExecutionResponse{iocExecution()}, STIXOpts{})
+	if again.ID != bundle.ID {
+		t.Errorf("bundle ID = %s, want %s on a second export", again.ID, bundle.ID)
+	}
+}
+
+func TestSTIXTechniquesWithoutIndicators(t *testing.T) {
+	bundle := STIX([]models.GetExecutionResponse{nestedExecution()}, STIXOpts{
+		Techniques: TechniqueMap{
+			"act-1": {{ID: "T1059.001", Tactics: []string{"Execution"}}},
+			"act-2": {{ID: "T1003", Tactics: []string{"Credential Access"}}},
+		},
+	})
+
+	var patterns []string
+	var report STIXObject
+	for _, o := range bundle.Objects {
+		switch o.Type {
+		case "attack-pattern":
+			patterns = append(patterns, o.ID)
+		case "report":
+			report = o
+		case "indicator", "relationship":
+			t.Errorf("unexpected %s without IOCs", o.Type)
+		}
+	}
+
+	// The techniques of nested steps without IOCs are exported once each and referenced by the report
+	if len(patterns) != 2 {
+		t.Fatalf("%d attack patterns, want 2", len(patterns))
+	}
+	if !slices.Equal(report.ObjectRefs, patterns) {
+		t.Errorf("report references %v, want the attack patterns %v", report.ObjectRefs, patterns)
+	}
+}
+
+func TestSTIXIndicatorName(t *testing.T) {
+	if got := stixIndicatorName(IOCRecord{Name: "Sigma rule", Type: "sigma", Value: "title: x"}); got != "Sigma rule" {
+		t.Errorf("stixIndicatorName() of a named rule = %q", got)
+	}
+	if got := stixIndicatorName(IOCRecord{Type: "url", Value: "http://a"}); got != "url: http://a" {
+		t.Errorf("stixIndicatorName() = %q", got)
+	}
+
+	// Long values are truncated on character boundaries
+	got := stixIndicatorName(IOCRecord{Type: "file", Value: strings.Repeat("é", 100)})
+	if want := "file: " + strings.Repeat("é", 77) + "..."; got != want || !utf8.ValidString(got) {
+		t.Errorf("stixIndicatorName() of a long value = %q, want %q", got, want)
+	}
+}
+
+func TestSTIXRuleType(t *testing.T) {
+	tests := map[string]string{
+		"sigma":       "sigma",
+		"YARA":        "yara",
+		"Sigma Rules": "sigma",
+		"snort rule":  "snort",
+		"regex":       "regex",
+		"stix":        "stix",
+	}
+	for typ, want := range tests {
+		if got := stixRuleType(typ); got != want {
+			t.Errorf("stixRuleType(%q) = %q, want %q", typ, got, want)
+		}
+	}
+}
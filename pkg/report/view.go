@@ -0,0 +1,235 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fourcorelabs/attack-sdk-go/pkg/executions"
+	"github.com/fourcorelabs/attack-sdk-go/pkg/models"
+)
+
+// templateFuncs are the functions available to every report template
+var templateFuncs = map[string]any{
+	"formatTime": formatTime,
+	"percent":    func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
+	"join":       strings.Join,
+	"formatBool": formatBool,
+	"outcome":    StepOutcome,
+}
+
+// Summary represents the step outcome totals of an execution or asset
+type Summary struct {
+	Steps         int     `json:"steps"`
+	Prevented     int     `json:"prevented"`
+	Detected      int     `json:"detected"`
+	Logged        int     `json:"logged"`
+	Undetected    int     `json:"undetected"`
+	Pending       int     `json:"pending"`
+	DetectionRate float64 `json:"detection_rate"` // Percentage of finished steps that were prevented or detected
+}
+
+// add counts a step outcome
+func (s *Summary) add(o Outcome) {
+	s.Steps++
+	switch o {
+	case OutcomePrevented:
+		s.Prevented++
+	case OutcomeDetected:
+		s.Detected++
+	case OutcomeLogged:
+		s.Logged++
+	case OutcomeUndetected:
+		s.Undetected++
+	case OutcomePending:
+		s.Pending++
+	}
+	if finished := s.Steps - s.Pending; finished > 0 {
+		s.DetectionRate = float64(s.Prevented+s.Detected) * 100 / float64(finished)
+	}
+}
+
+// executionView is the data passed to the execution report templates
+type executionView struct {
+	Title       string
+	GeneratedAt time.Time
+	Execution   models.GetExecutionResponse
+	Summary     Summary
+	Assets      []assetView
+	Timeline    []eventView
+	Techniques  []techniqueView
+	ShowMitre   bool
+}
+
+// assetView is an asset of the execution with its classified steps
+type assetView struct {
+	models.AssetExecutionDetails
+	Name    string
+	Summary Summary
+	Steps   []stepView
+}
+
+// stepView is a step with its outcome and MITRE ATT&CK techniques
+type stepView struct {
+	models.GetExecutionResponseAssetStep
+	Label      string // Name of the step preceded by the steps enclosing it
+	Depth      int    // 0 for the steps of an asset, 1 for their action steps, and so on
+	Outcome    Outcome
+	Duration   time.Duration
+	Techniques []Technique
+}
+
+// eventView is an entry of the execution timeline
+type eventView struct {
+	Time     time.Time
+	Hostname string
+	Step     string
+	Type     string
+	Data     string
+}
+
+// techniqueView is a MITRE ATT&CK technique exercised by the execution with its step outcomes
+type techniqueView struct {
+	Technique
+	Summary Summary
+	Steps   []string
+}
+
+// newExecutionView builds the template data of an execution report
+func newExecutionView(e models.GetExecutionResponse, title string, techniqueMap TechniqueMap, now time.Time) executionView {
+	r := executionView{
+		Title:       title,
+		GeneratedAt: now,
+		Execution:   e,
+		ShowMitre:   techniqueMap != nil,
+	}
+	if r.Title == "" {
+		r.Title = valueOr(e.AttackName, "Execution "+e.ID)
+	}
+	if r.GeneratedAt.IsZero() {
+		r.GeneratedAt = time.Now()
+	}
+
+	techniques := map[string]*techniqueView{}
+	for _, event := range e.Events {
+		r.Timeline = append(r.Timeline, eventView{Time: event.EventTime, Hostname: event.Hostname, Type: event.Type, Data: event.Data})
+	}
+
+	assetIndex := map[*models.AssetExecutionDetails]int{}
+	for i := range e.Assets {
+		assetIndex[&e.Assets[i]] = i
+		r.Assets = append(r.Assets, assetView{AssetExecutionDetails: e.Assets[i], Name: hostname(e.Assets[i])})
+	}
+
+	for v := range executions.Steps(e) {
+		a := &r.Assets[assetIndex[v.Asset]]
+		step := *v.Step
+		s := stepView{
+			GetExecutionResponseAssetStep: step,
+			Label:                         stepLabel(v),
+			Depth:                         v.Depth,
+			Outcome:                       StepOutcome(step),
+			Duration:                      stepDuration(step),
+			Techniques:                    techniqueMap.Lookup(step.ActionID),
+		}
+		a.Summary.add(s.Outcome)
+		r.Summary.add(s.Outcome)
+
+		for _, t := range s.Techniques {
+			ht, ok := techniques[t.ID]
+			if !ok {
+				ht = &techniqueView{Technique: t}
+				techniques[t.ID] = ht
+			}
+			ht.Summary.add(s.Outcome)
+			ht.Steps = append(ht.Steps, fmt.Sprintf("%s (%s)", s.Label, a.Name))
+		}
+
+		for _, event := range step.Events {
+			r.Timeline = append(r.Timeline, eventView{
+				Time:     event.EventTime,
+				Hostname: valueOr(event.Hostname, a.Name),
+				Step:     s.Label,
+				Type:     event.Type,
+				Data:     event.Data,
+			})
+		}
+
+		a.Steps = append(a.Steps, s)
+	}
+
+	slices.SortStableFunc(r.Timeline, func(a, b eventView) int { return a.Time.Compare(b.Time) })
+
+	for _, t := range techniques {
+		r.Techniques = append(r.Techniques, *t)
+	}
+	slices.SortFunc(r.Techniques, func(a, b techniqueView) int { return strings.Compare(a.ID, b.ID) })
+
+	return r
+}
+
+// formatTime formats an optional or plain time as RFC3339, with unset times as N/A
+func formatTime(t any) string {
+	switch t := t.(type) {
+	case time.Time:
+		if !t.IsZero() {
+			return t.Format(time.RFC3339)
+		}
+	case *time.Time:
+		if t != nil && !t.IsZero() {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return "N/A"
+}
+
+// valueOr returns s, or def if s is empty
+func valueOr(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// stepsView is the data passed to the steps report templates
+type stepsView struct {
+	Summary Summary
+	Steps   []stepDetectionView
+}
+
+// stepDetectionView is a step annotated with its asset and outcome
+type stepDetectionView struct {
+	models.ExecutionStepDetections
+	Outcome Outcome
+}
+
+// newStepsView builds the template data of a steps report
+func newStepsView(steps []models.ExecutionStepDetections) stepsView {
+	var v stepsView
+	for _, step := range steps {
+		s := stepDetectionView{ExecutionStepDetections: step, Outcome: StepOutcome(step.GetExecutionResponseAssetStep)}
+		v.Summary.add(s.Outcome)
+		v.Steps = append(v.Steps, s)
+	}
+	return v
+}
+
+// packView is a pack run with its detection rate
+type packView struct {
+	models.PackRun
+	DetectionRate float64 // Percentage of attacks that were detected, or 0 if the pack has no attacks
+}
+
+// newPacksView builds the template data of a pack runs report
+func newPacksView(packs []models.PackRun) []packView {
+	views := make([]packView, 0, len(packs))
+	for _, pack := range packs {
+		v := packView{PackRun: pack}
+		if pack.Total > 0 {
+			v.DetectionRate = float64(pack.Detected) * 100 / float64(pack.Total)
+		}
+		views = append(views, v)
+	}
+	return views
+}